@@ -1,8 +1,10 @@
 package code
 
 import (
+	"go/token"
 	"go/types"
 	"reflect"
+	"strings"
 )
 
 // StructField is a definition of the struct field
@@ -11,6 +13,22 @@ type StructField struct {
 	Tag reflect.StructTag
 }
 
+// FindIDField finds the struct field that is stored as the document ID, i.e.
+// the field with `_id` bson tag.
+func FindIDField(structModel *types.Struct) (StructField, bool) {
+	for i := 0; i < structModel.NumFields(); i++ {
+		tag := reflect.StructTag(structModel.Tag(i))
+		bsonTag := strings.Split(tag.Get("bson"), ",")[0]
+		if bsonTag == "_id" {
+			return StructField{
+				Var: structModel.Field(i),
+				Tag: tag,
+			}, true
+		}
+	}
+	return StructField{}, false
+}
+
 var (
 	TypeBool    = types.Typ[types.Bool]
 	TypeInt     = types.Typ[types.Int]
@@ -19,3 +37,30 @@ var (
 	TypeString  = types.Typ[types.String]
 	TypeError   = types.Universe.Lookup("error").Type()
 )
+
+// TypeDuration is a representation of time.Duration. Since it is not loaded
+// from the actual time package, it cannot be compared with types.Identical.
+// Compare its string representation instead.
+var TypeDuration = types.NewNamed(
+	types.NewTypeName(token.NoPos, types.NewPackage("time", "time"), "Duration", nil),
+	types.Typ[types.Int64],
+	nil,
+)
+
+// IsTime returns true if the given type is time.Time or primitive.DateTime
+// (with or without pointer).
+func IsTime(t types.Type) bool {
+	if pointerType, ok := t.(*types.Pointer); ok {
+		t = pointerType.Elem()
+	}
+	return t.String() == "time.Time" || IsDateTime(t)
+}
+
+// IsDateTime returns true if the given type is primitive.DateTime (with or
+// without pointer).
+func IsDateTime(t types.Type) bool {
+	if pointerType, ok := t.(*types.Pointer); ok {
+		t = pointerType.Elem()
+	}
+	return t.String() == "go.mongodb.org/mongo-driver/bson/primitive.DateTime"
+}
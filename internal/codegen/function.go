@@ -37,21 +37,29 @@ func (fb FunctionBuilder) Impl(buffer *bytes.Buffer) error {
 }
 
 func (fb FunctionBuilder) GenParams() string {
-	return generateParams(fb.Pkg, fb.Params)
+	return generateParams(fb.Pkg, fb.Params, false)
 }
 
 func (fb FunctionBuilder) GenReturns() string {
 	return generateReturns(fb.Pkg, fb.Returns)
 }
 
-func generateParams(pkg *types.Package, params *types.Tuple) string {
+// generateParams generates the parameter list. If variadic is true, the last
+// parameter, which must be of a slice type, is rendered as a variadic
+// parameter.
+func generateParams(pkg *types.Package, params *types.Tuple, variadic bool) string {
 	var paramList []string
 	for i := 0; i < params.Len(); i++ {
 		param := params.At(i)
 
+		typeString := TypeToString(pkg, param.Type())
+		if variadic && i == params.Len()-1 {
+			typeString = "..." + TypeToString(pkg, param.Type().(*types.Slice).Elem())
+		}
+
 		paramList = append(
 			paramList,
-			fmt.Sprintf("%s %s", param.Name(), TypeToString(pkg, param.Type())),
+			fmt.Sprintf("%s %s", param.Name(), typeString),
 		)
 	}
 	return strings.Join(paramList, ", ")
@@ -8,17 +8,23 @@ import (
 )
 
 const methodTemplate = `
-func ({{.GenReceiver}}) {{.Name}}({{.GenParams}}){{.GenReturns}} {
+{{range .Doc}}{{.}}
+{{end}}func ({{.GenReceiver}}) {{.Name}}({{.GenParams}}){{.GenReturns}} {
 {{.Body.Code}}
 }
 `
 
 // MethodBuilder is an implementer of a method.
 type MethodBuilder struct {
-	Pkg      *types.Package
+	Pkg *types.Package
+	// Doc is the lines of the doc comment of the method including the comment
+	// markers.
+	Doc      []string
 	Receiver MethodReceiver
 	Name     string
 	Params   *types.Tuple
+	// Variadic indicates that the last parameter is a variadic parameter.
+	Variadic bool
 	Returns  []types.Type
 	Body     FunctionBody
 }
@@ -58,7 +64,7 @@ func (mb MethodBuilder) generateReceiverType() string {
 }
 
 func (mb MethodBuilder) GenParams() string {
-	return generateParams(mb.Pkg, mb.Params)
+	return generateParams(mb.Pkg, mb.Params, mb.Variadic)
 }
 
 func (mb MethodBuilder) GenReturns() string {
@@ -185,3 +185,103 @@ func (u *User) SetAge(age int) {
 		t.Error(err)
 	}
 }
+
+func TestMethodBuilderBuild_VariadicParam(t *testing.T) {
+	fb := codegen.MethodBuilder{
+		Receiver: codegen.MethodReceiver{
+			Name:     "u",
+			TypeName: "User",
+			Pointer:  true,
+		},
+		Name: "AddTags",
+		Params: types.NewTuple(
+			types.NewVar(token.NoPos, nil, "prefix", code.TypeString),
+			types.NewVar(token.NoPos, nil, "tags", types.NewSlice(code.TypeString)),
+		),
+		Variadic: true,
+		Returns:  nil,
+		Body: codegen.FunctionBody{
+			codegen.AssignStatement{
+				Vars: []string{"u.Tags"},
+				Values: codegen.StatementList{
+					codegen.CallStatement{
+						FuncName: "append",
+						Params: codegen.StatementList{
+							codegen.Identifier("u.Tags"),
+							codegen.Identifier("tags..."),
+						},
+					},
+				},
+			},
+		},
+	}
+	expectedCode := `
+func (u *User) AddTags(prefix string, tags ...string) {
+	u.Tags = append(u.Tags, tags...)
+}
+`
+	buffer := new(bytes.Buffer)
+
+	err := fb.Impl(buffer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := buffer.String()
+	if err := testutils.ExpectMultiLineString(
+		expectedCode,
+		actual,
+	); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMethodBuilderBuild_Doc(t *testing.T) {
+	fb := codegen.MethodBuilder{
+		Doc: []string{
+			"// SetAge sets the age of the user.",
+			"//",
+			"// The age must not be negative.",
+		},
+		Receiver: codegen.MethodReceiver{
+			Name:     "u",
+			TypeName: "User",
+			Pointer:  true,
+		},
+		Name: "SetAge",
+		Params: types.NewTuple(
+			types.NewVar(token.NoPos, nil, "age", code.TypeInt),
+		),
+		Returns: nil,
+		Body: codegen.FunctionBody{
+			codegen.AssignStatement{
+				Vars: []string{"u.Age"},
+				Values: codegen.StatementList{
+					codegen.Identifier("age"),
+				},
+			},
+		},
+	}
+	expectedCode := `
+// SetAge sets the age of the user.
+//
+// The age must not be negative.
+func (u *User) SetAge(age int) {
+	u.Age = age
+}
+`
+	buffer := new(bytes.Buffer)
+
+	err := fb.Impl(buffer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := buffer.String()
+	if err := testutils.ExpectMultiLineString(
+		expectedCode,
+		actual,
+	); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"strings"
+	"text/template"
+)
+
+const varTemplate = `
+var (
+{{.GenVars}}
+)
+`
+
+// VarBuilder is an implementer of package-level variable declarations.
+type VarBuilder struct {
+	Pkg  *types.Package
+	Vars []Var
+}
+
+// Var is a package-level variable with its initial value. The type is
+// optional and is inferred from the value if not given.
+type Var struct {
+	Name  string
+	Type  types.Type
+	Value Statement
+}
+
+// Impl writes variable declaration code to the buffer. Nothing is written if
+// there is no variable to declare.
+func (vb VarBuilder) Impl(buffer *bytes.Buffer) error {
+	if len(vb.Vars) == 0 {
+		return nil
+	}
+
+	tmpl, err := template.New("var").Parse(varTemplate)
+	if err != nil {
+		return err
+	}
+
+	// writing to a buffer should not cause errors.
+	_ = tmpl.Execute(buffer, vb)
+
+	return nil
+}
+
+func (vb VarBuilder) GenVars() string {
+	var varLines []string
+	for _, v := range vb.Vars {
+		decl := v.Name
+		if v.Type != nil {
+			decl += " " + TypeToString(vb.Pkg, v.Type)
+		}
+
+		lines := v.Value.CodeLines()
+		lines[0] = fmt.Sprintf("%s = %s", decl, lines[0])
+		for _, line := range lines {
+			varLines = append(varLines, "\t"+line)
+		}
+	}
+	return strings.Join(varLines, "\n")
+}
@@ -0,0 +1,104 @@
+package codegen_test
+
+import (
+	"bytes"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/sunboyy/repogen/internal/codegen"
+	"github.com/sunboyy/repogen/internal/testutils"
+)
+
+const expectedVarBuilderCode = `
+var (
+	ErrNotFound = errors.New("not found")
+	ErrDuplicateKey = errors.New("duplicate key")
+)
+`
+
+func TestVarBuilderBuild(t *testing.T) {
+	vb := codegen.VarBuilder{
+		Vars: []codegen.Var{
+			{
+				Name: "ErrNotFound",
+				Value: codegen.CallStatement{
+					FuncName: "errors.New",
+					Params: codegen.StatementList{
+						codegen.Identifier(`"not found"`),
+					},
+				},
+			},
+			{
+				Name: "ErrDuplicateKey",
+				Value: codegen.CallStatement{
+					FuncName: "errors.New",
+					Params: codegen.StatementList{
+						codegen.Identifier(`"duplicate key"`),
+					},
+				},
+			},
+		},
+	}
+	buffer := new(bytes.Buffer)
+
+	err := vb.Impl(buffer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := buffer.String()
+	if err := testutils.ExpectMultiLineString(
+		expectedVarBuilderCode,
+		actual,
+	); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVarBuilderBuild_Type(t *testing.T) {
+	pkg := types.NewPackage("example.com/repo", "repo")
+	intfType := types.NewNamed(
+		types.NewTypeName(token.NoPos, types.NewPackage("example.com/user", "user"), "UserRepository", nil),
+		nil, nil)
+	vb := codegen.VarBuilder{
+		Pkg: pkg,
+		Vars: []codegen.Var{
+			{
+				Name:  "_",
+				Type:  intfType,
+				Value: codegen.RawStatement("(*UserRepositoryMongo)(nil)"),
+			},
+		},
+	}
+	expectedCode := `
+var (
+	_ user.UserRepository = (*UserRepositoryMongo)(nil)
+)
+`
+	buffer := new(bytes.Buffer)
+
+	err := vb.Impl(buffer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := buffer.String()
+	if err := testutils.ExpectMultiLineString(expectedCode, actual); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVarBuilderBuild_Empty(t *testing.T) {
+	vb := codegen.VarBuilder{}
+	buffer := new(bytes.Buffer)
+
+	err := vb.Impl(buffer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("expected no code, got %s", buffer.String())
+	}
+}
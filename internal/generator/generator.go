@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"go/ast"
+	"go/token"
 	"go/types"
 	"log"
 
@@ -9,22 +11,49 @@ import (
 	"github.com/sunboyy/repogen/internal/spec"
 )
 
+// Options is the optional configuration of the generated repository
+// implementation.
+type Options struct {
+	// RepoSyntax is the syntax trees of the repository package. It is used to
+	// read the doc comments of the repository methods.
+	RepoSyntax []*ast.File
+	// NotFound is the behavior of the find one methods when no document
+	// matches the query. It can be overridden by each method with
+	// `repogen:notfound=` doc comment directive.
+	NotFound mongo.NotFoundBehavior
+	// WrapErrors wraps the errors returned by the generated methods with the
+	// repository and method name, and classifies them into the generated
+	// sentinel errors.
+	WrapErrors bool
+	// ImplName is the name of the implementation struct. It defaults to the
+	// repository interface name suffixed with Mongo.
+	ImplName string
+	// UnexportedImpl unexports the implementation struct by lowercasing the
+	// first letter of its name. The constructor then returns the repository
+	// interface.
+	UnexportedImpl bool
+	// ConstructorName is the name of the constructor of the implementation
+	// struct. It defaults to the repository interface name prefixed with New.
+	ConstructorName string
+}
+
 func GenerateRepositoryImpl(modelPkg, repoPkg, destPkg *types.Package, structModelName,
-	repoInterfaceName string) (string, error) {
+	repoInterfaceName string, options Options) (string, error) {
 
-	namedStruct, intf, err := deriveSourceTypes(modelPkg, repoPkg, structModelName,
+	namedStruct, namedIntf, err := deriveSourceTypes(modelPkg, repoPkg, structModelName,
 		repoInterfaceName)
 	if err != nil {
 		return "", err
 	}
 
-	methodSpecs, err := constructRepositorySpec(repoPkg, namedStruct, intf)
+	methodSpecs, err := constructRepositorySpec(repoPkg, namedStruct,
+		namedIntf.Underlying().(*types.Interface), extractMethodDocs(options.RepoSyntax))
 	if err != nil {
 		return "", err
 	}
 
 	codeBuilder, err := constructCodeBuilder(destPkg, namedStruct,
-		repoInterfaceName, methodSpecs)
+		namedIntf, methodSpecs, options)
 	if err != nil {
 		return "", err
 	}
@@ -33,7 +62,7 @@ func GenerateRepositoryImpl(modelPkg, repoPkg, destPkg *types.Package, structMod
 }
 
 func deriveSourceTypes(modelPkg, repoPkg *types.Package, structModelName string,
-	repositoryInterfaceName string) (*types.Named, *types.Interface, error) {
+	repositoryInterfaceName string) (*types.Named, *types.Named, error) {
 
 	structModelObj := modelPkg.Scope().Lookup(structModelName)
 	if structModelObj == nil {
@@ -48,16 +77,19 @@ func deriveSourceTypes(modelPkg, repoPkg *types.Package, structModelName string,
 	if intfObj == nil {
 		return nil, nil, ErrInterfaceNotFound
 	}
-	intf, ok := intfObj.Type().Underlying().(*types.Interface)
+	namedIntf, ok := intfObj.Type().(*types.Named)
 	if !ok {
 		return nil, nil, ErrNotInterface
 	}
+	if _, ok := namedIntf.Underlying().(*types.Interface); !ok {
+		return nil, nil, ErrNotInterface
+	}
 
-	return namedStruct, intf, nil
+	return namedStruct, namedIntf, nil
 }
 
 func constructRepositorySpec(pkg *types.Package, namedStruct *types.Named,
-	intf *types.Interface) ([]spec.MethodSpec, error) {
+	intf *types.Interface, methodDocs map[token.Pos][]string) ([]spec.MethodSpec, error) {
 
 	var methodSpecs []spec.MethodSpec
 	for i := 0; i < intf.NumMethods(); i++ {
@@ -68,29 +100,70 @@ func constructRepositorySpec(pkg *types.Package, namedStruct *types.Named,
 		if err != nil {
 			return nil, err
 		}
+		methodSpec.Doc = methodDocs[method.Pos()]
 		methodSpecs = append(methodSpecs, methodSpec)
 	}
 
 	return methodSpecs, nil
 }
 
-func constructCodeBuilder(pkg *types.Package, namedStruct *types.Named,
-	interfaceName string, methodSpecs []spec.MethodSpec) (*codegen.Builder, error) {
+// extractMethodDocs maps the position of each interface method declared in the
+// syntax trees to the lines of its doc comment.
+func extractMethodDocs(files []*ast.File) map[token.Pos][]string {
+	methodDocs := make(map[token.Pos][]string)
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			intfType, ok := node.(*ast.InterfaceType)
+			if !ok {
+				return true
+			}
+
+			for _, field := range intfType.Methods.List {
+				if field.Doc == nil {
+					continue
+				}
+
+				var lines []string
+				for _, comment := range field.Doc.List {
+					lines = append(lines, comment.Text)
+				}
+				for _, name := range field.Names {
+					methodDocs[name.Pos()] = lines
+				}
+			}
+			return true
+		})
+	}
+	return methodDocs
+}
 
-	generator := mongo.NewGenerator(pkg, namedStruct, interfaceName)
+func constructCodeBuilder(pkg *types.Package, namedStruct *types.Named,
+	namedIntf *types.Named, methodSpecs []spec.MethodSpec, options Options) (*codegen.Builder, error) {
+
+	generator := mongo.NewGenerator(pkg, namedStruct, namedIntf.Obj().Name())
+	generator.NotFound = options.NotFound
+	generator.WrapErrors = options.WrapErrors
+	generator.ImplName = options.ImplName
+	generator.UnexportedImpl = options.UnexportedImpl
+	generator.ConstructorName = options.ConstructorName
 	codeBuilder := codegen.NewBuilder(
 		"repogen",
 		pkg.Name(),
 		generator.Imports(),
 	)
 
-	constructorBuilder, err := generator.GenerateConstructor()
+	constructorBuilder, err := generator.GenerateConstructor(namedIntf)
 	if err != nil {
 		return nil, err
 	}
 
+	codeBuilder.AddImplementer(generator.GenerateErrors(methodSpecs))
+	if options.WrapErrors {
+		codeBuilder.AddImplementer(generator.GenerateErrorClassifier())
+	}
 	codeBuilder.AddImplementer(constructorBuilder)
 	codeBuilder.AddImplementer(generator.GenerateStruct())
+	codeBuilder.AddImplementer(generator.GenerateInterfaceAssertion(namedIntf))
 
 	for _, method := range methodSpecs {
 		methodBuilder, err := generator.GenerateMethod(method)
@@ -2,10 +2,14 @@ package generator_test
 
 import (
 	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"testing"
 
 	"github.com/sunboyy/repogen/internal/generator"
+	"github.com/sunboyy/repogen/internal/mongo"
 	"github.com/sunboyy/repogen/internal/testutils"
 )
 
@@ -27,6 +31,9 @@ func TestGenerateRepository_Success(t *testing.T) {
 		testutils.Pkg,
 		validStructModelName,
 		validRepoInterfaceName,
+		generator.Options{
+			RepoSyntax: testutils.Syntax,
+		},
 	)
 
 	if err != nil {
@@ -37,6 +44,62 @@ func TestGenerateRepository_Success(t *testing.T) {
 	}
 }
 
+func TestGenerateRepositoryImpl_MultipleRepositoriesInPackage(t *testing.T) {
+	options := generator.Options{
+		RepoSyntax: testutils.Syntax,
+		NotFound:   mongo.NotFoundSentinel,
+		WrapErrors: true,
+	}
+	declared := make(map[string]string)
+
+	for _, repoInterfaceName := range []string{validRepoInterfaceName, "UserRepositoryFind"} {
+		code, err := generator.GenerateRepositoryImpl(testutils.Pkg, testutils.Pkg, testutils.Pkg,
+			validStructModelName, repoInterfaceName, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), "", code, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range topLevelNames(file) {
+			if other, ok := declared[name]; ok {
+				t.Errorf("%s is declared by both %s and %s", name, other, repoInterfaceName)
+			}
+			declared[name] = repoInterfaceName
+		}
+	}
+}
+
+// topLevelNames returns the names declared at the package level of the file,
+// excluding the methods and the blank identifiers.
+func topLevelNames(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names = append(names, decl.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if name.Name != "_" {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
 func TestGenerateRepositoryImpl_StructNotFound(t *testing.T) {
 	_, err := generator.GenerateRepositoryImpl(
 		testutils.Pkg,
@@ -44,6 +107,7 @@ func TestGenerateRepositoryImpl_StructNotFound(t *testing.T) {
 		testutils.Pkg,
 		"UnknownModel",
 		validRepoInterfaceName,
+		generator.Options{},
 	)
 
 	expectedError := generator.ErrStructNotFound
@@ -59,6 +123,7 @@ func TestGenerateRepositoryImpl_ModelNameNotStruct(t *testing.T) {
 		testutils.Pkg,
 		"UserRepositoryFind",
 		validRepoInterfaceName,
+		generator.Options{},
 	)
 
 	expectedError := generator.ErrNotNamedStruct
@@ -74,6 +139,7 @@ func TestGenerateRepositoryImpl_InterfaceNotFound(t *testing.T) {
 		testutils.Pkg,
 		validStructModelName,
 		"UnknownRepository",
+		generator.Options{},
 	)
 
 	expectedError := generator.ErrInterfaceNotFound
@@ -89,6 +155,7 @@ func TestGenerateRepositoryImpl_RepoNameNotInterface(t *testing.T) {
 		testutils.Pkg,
 		validStructModelName,
 		"User",
+		generator.Options{},
 	)
 
 	expectedError := generator.ErrNotInterface
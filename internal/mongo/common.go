@@ -1,8 +1,10 @@
 package mongo
 
 import (
+	"fmt"
 	"go/token"
 	"go/types"
+	"reflect"
 	"strings"
 
 	"github.com/sunboyy/repogen/internal/code"
@@ -66,6 +68,31 @@ var ifErrReturnFalseErr = codegen.IfBlock{
 type baseMethodGenerator struct {
 	targetPkg        *types.Package
 	structModelNamed *types.Named
+	// paramNames is the names of the parameters of the generated method.
+	paramNames []string
+}
+
+// param returns the name of the i-th parameter of the generated method,
+// falling back to argN if the name is not given.
+func (g baseMethodGenerator) param(i int) string {
+	if i < len(g.paramNames) {
+		return g.paramNames[i]
+	}
+	return fmt.Sprintf("arg%d", i)
+}
+
+// predicateParams returns the names of the parameters that hold the
+// arguments of the predicate.
+func (g baseMethodGenerator) predicateParams(predicateSpec spec.Predicate) []string {
+	if len(predicateSpec.ParamFields) > 0 {
+		return []string{g.param(predicateSpec.ParamIndex)}
+	}
+
+	var params []string
+	for i := 0; i < predicateSpec.NumberOfArguments(); i++ {
+		params = append(params, g.param(predicateSpec.ParamIndex+i))
+	}
+	return params
 }
 
 func (g baseMethodGenerator) bsonFieldReference(fieldReference spec.FieldReference) (string, error) {
@@ -90,6 +117,112 @@ func (g baseMethodGenerator) bsonTagFromField(field code.StructField) (string, e
 	return documentKey, nil
 }
 
+// idField finds the model field that is stored as the document ID, i.e. the
+// field with `_id` bson tag.
+func (g baseMethodGenerator) idField() (code.StructField, error) {
+	idField, ok := code.FindIDField(g.structModelNamed.Underlying().(*types.Struct))
+	if !ok {
+		return code.StructField{}, ErrIDFieldNotFound
+	}
+	return idField, nil
+}
+
+// timestamp tag values of the repogen struct tag
+const (
+	timestampCreatedAt = "createdAt"
+	timestampUpdatedAt = "updatedAt"
+)
+
+// timestampField is a model field that repogen automatically sets to the
+// current time. It is declared by repogen struct tag.
+type timestampField struct {
+	Name    string
+	BsonTag string
+	Type    types.Type
+}
+
+// timeValue converts time.Time statement to the given time field type.
+func timeValue(fieldType types.Type, timeStmt codegen.Statement) codegen.Statement {
+	if code.IsDateTime(fieldType) {
+		return codegen.CallStatement{
+			FuncName: "primitive.NewDateTimeFromTime",
+			Params:   codegen.StatementList{timeStmt},
+		}
+	}
+	return timeStmt
+}
+
+// timestampField finds the field of the model struct whose repogen struct tag
+// matches the given timestamp kind.
+func (g baseMethodGenerator) timestampField(kind string) (timestampField, bool, error) {
+	structModel := g.structModelNamed.Underlying().(*types.Struct)
+
+	for i := 0; i < structModel.NumFields(); i++ {
+		field := code.StructField{
+			Var: structModel.Field(i),
+			Tag: reflect.StructTag(structModel.Tag(i)),
+		}
+
+		repogenTag, ok := field.Tag.Lookup("repogen")
+		if !ok || repogenTag != kind {
+			continue
+		}
+
+		if _, ok := field.Var.Type().(*types.Pointer); ok || !code.IsTime(field.Var.Type()) {
+			return timestampField{}, false, NewTimestampFieldTypeError(field.Var.Name())
+		}
+
+		bsonTag, err := g.bsonTagFromField(field)
+		if err != nil {
+			return timestampField{}, false, err
+		}
+
+		return timestampField{
+			Name:    field.Var.Name(),
+			BsonTag: bsonTag,
+			Type:    field.Var.Type(),
+		}, true, nil
+	}
+
+	return timestampField{}, false, nil
+}
+
+// timestampFields returns the timestamp fields of the given kinds that are
+// declared in the model struct.
+func (g baseMethodGenerator) timestampFields(kinds ...string) ([]timestampField, error) {
+	var fields []timestampField
+	for _, kind := range kinds {
+		field, ok, err := g.timestampField(kind)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// timestampAssignStatements generates statements that set the timestamp fields
+// of the model to the current time.
+func timestampAssignStatements(model string, fields []timestampField) []codegen.Statement {
+	var stmts []codegen.Statement
+	for _, field := range fields {
+		stmts = append(stmts, codegen.AssignStatement{
+			Vars:   []string{model + "." + field.Name},
+			Values: codegen.StatementList{timeValue(field.Type, codegen.Identifier("now"))},
+		})
+	}
+	return stmts
+}
+
+var declareNow = codegen.DeclAssignStatement{
+	Vars: []string{"now"},
+	Values: codegen.StatementList{
+		codegen.NewChainBuilder("time").Call("Now").Build(),
+	},
+}
+
 func (g baseMethodGenerator) convertQuerySpec(query spec.QuerySpec) (querySpec, error) {
 	var predicates []predicate
 
@@ -99,10 +232,28 @@ func (g baseMethodGenerator) convertQuerySpec(query spec.QuerySpec) (querySpec,
 			return querySpec{}, err
 		}
 
+		var fieldType types.Type
+		if len(predicateSpec.FieldReference) > 0 {
+			fieldType = predicateSpec.FieldReference.ReferencedField().Var.Type()
+		}
+
+		var comparedBsonFieldReference string
+		if predicateSpec.ComparedFieldReference != nil {
+			comparedBsonFieldReference, err = g.bsonFieldReference(predicateSpec.ComparedFieldReference)
+			if err != nil {
+				return querySpec{}, err
+			}
+		}
+
 		predicates = append(predicates, predicate{
-			Field:      bsonFieldReference,
-			Comparator: predicateSpec.Comparator,
-			ParamIndex: predicateSpec.ParamIndex,
+			Field:         bsonFieldReference,
+			FieldType:     fieldType,
+			Comparator:    predicateSpec.Comparator,
+			Params:        g.predicateParams(predicateSpec),
+			Negated:       predicateSpec.Negated,
+			ComparedField: comparedBsonFieldReference,
+			ParamFields:   predicateSpec.ParamFields,
+			Optional:      predicateSpec.Optional,
 		})
 	}
 
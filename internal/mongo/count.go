@@ -13,14 +13,14 @@ func (g RepositoryGenerator) generateCountBody(
 		return nil, err
 	}
 
-	return codegen.FunctionBody{
+	return append(querySpec.Statements(),
 		codegen.DeclAssignStatement{
 			Vars: []string{"count", "err"},
 			Values: codegen.StatementList{
 				codegen.NewChainBuilder("r").
 					Chain("collection").
 					Call("CountDocuments",
-						codegen.Identifier("arg0"),
+						codegen.Identifier(g.param(0)),
 						querySpec.Code(),
 					).Build(),
 			},
@@ -35,5 +35,5 @@ func (g RepositoryGenerator) generateCountBody(
 			},
 			codegen.Identifier("nil"),
 		},
-	}, nil
+	), nil
 }
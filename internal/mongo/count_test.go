@@ -422,6 +422,69 @@ func TestGenerateMethod_Count(t *testing.T) {
 	if err != nil {
 		return 0, err
 	}
+	return int(count), nil`,
+		},
+		{
+			Name: "count with optional predicates and Or operator",
+			MethodSpec: spec.MethodSpec{
+				Name: "CountByCityOrGender",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeUserQueryNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.CountOperation{
+					Query: spec.QuerySpec{
+						Operator: spec.OperatorOr,
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+								},
+								Comparator:  spec.ComparatorEqual,
+								ParamIndex:  1,
+								ParamFields: []string{"City"},
+								Optional:    true,
+							},
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "LastLogin"),
+								},
+								Comparator:  spec.ComparatorWithinLast,
+								ParamIndex:  1,
+								ParamFields: []string{"LastLogin"},
+								Optional:    true,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	var conditions []bson.M
+	if arg1.City != nil {
+		conditions = append(conditions, bson.M{
+			"city": *arg1.City,
+		})
+	}
+	if arg1.LastLogin != nil {
+		conditions = append(conditions, bson.M{
+			"last_login": bson.M{
+				"$gte": primitive.NewDateTimeFromTime(time.Now().Add(-*arg1.LastLogin)),
+			},
+		})
+	}
+	filter := bson.M{}
+	if len(conditions) > 0 {
+		filter["$or"] = conditions
+	}
+	count, err := r.collection.CountDocuments(arg0, filter)
+	if err != nil {
+		return 0, err
+	}
 	return int(count), nil`,
 		},
 	}
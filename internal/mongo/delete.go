@@ -26,10 +26,10 @@ func (g deleteBodyGenerator) generate() (codegen.FunctionBody, error) {
 	}
 
 	if g.operation.Mode == spec.QueryModeOne {
-		return g.generateDeleteOneBody(querySpec), nil
+		return append(querySpec.Statements(), g.generateDeleteOneBody(querySpec)...), nil
 	}
 
-	return g.generateDeleteManyBody(querySpec), nil
+	return append(querySpec.Statements(), g.generateDeleteManyBody(querySpec)...), nil
 }
 
 func (g deleteBodyGenerator) generateDeleteOneBody(
@@ -42,7 +42,7 @@ func (g deleteBodyGenerator) generateDeleteOneBody(
 				codegen.NewChainBuilder("r").
 					Chain("collection").
 					Call("DeleteOne",
-						codegen.Identifier("arg0"),
+						codegen.Identifier(g.param(0)),
 						querySpec.Code(),
 					).Build(),
 			},
@@ -65,7 +65,7 @@ func (g deleteBodyGenerator) generateDeleteManyBody(
 				codegen.NewChainBuilder("r").
 					Chain("collection").
 					Call("DeleteMany",
-						codegen.Identifier("arg0"),
+						codegen.Identifier(g.param(0)),
 						querySpec.Code(),
 					).Build(),
 			},
@@ -1,11 +1,20 @@
 package mongo
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/sunboyy/repogen/internal/spec"
 )
 
+// ErrIDFieldNotFound is returned when the model has no field with `_id` bson
+// tag but the generated code requires it.
+var ErrIDFieldNotFound = errors.New("field with bson tag '_id' not found")
+
+// ErrOptionalArrayFilter is returned when the array element updated by the
+// filtered positional operator is queried by an optional predicate.
+var ErrOptionalArrayFilter = errors.New("optional predicate cannot be used with filtered positional operator")
+
 // NewOperationNotSupportedError creates operationNotSupportedError
 func NewOperationNotSupportedError(operationName string) error {
 	return operationNotSupportedError{OperationName: operationName}
@@ -19,6 +28,32 @@ func (err operationNotSupportedError) Error() string {
 	return fmt.Sprintf("operation '%s' not supported", err.OperationName)
 }
 
+// NewInvalidNotFoundBehaviorError creates invalidNotFoundBehaviorError
+func NewInvalidNotFoundBehaviorError(behavior string) error {
+	return invalidNotFoundBehaviorError{Behavior: behavior}
+}
+
+type invalidNotFoundBehaviorError struct {
+	Behavior string
+}
+
+func (err invalidNotFoundBehaviorError) Error() string {
+	return fmt.Sprintf("invalid not-found behavior '%s'", err.Behavior)
+}
+
+// NewInvalidIdentifierError creates invalidIdentifierError
+func NewInvalidIdentifierError(name string) error {
+	return invalidIdentifierError{Name: name}
+}
+
+type invalidIdentifierError struct {
+	Name string
+}
+
+func (err invalidIdentifierError) Error() string {
+	return fmt.Sprintf("invalid identifier '%s'", err.Name)
+}
+
 // NewBsonTagNotFoundError creates bsonTagNotFoundError
 func NewBsonTagNotFoundError(fieldName string) error {
 	return bsonTagNotFoundError{FieldName: fieldName}
@@ -57,3 +92,16 @@ type updateOperatorNotSupportedError struct {
 func (err updateOperatorNotSupportedError) Error() string {
 	return fmt.Sprintf("update operator %s not supported", err.Operator)
 }
+
+// NewTimestampFieldTypeError creates timestampFieldTypeError
+func NewTimestampFieldTypeError(fieldName string) error {
+	return timestampFieldTypeError{FieldName: fieldName}
+}
+
+type timestampFieldTypeError struct {
+	FieldName string
+}
+
+func (err timestampFieldTypeError) Error() string {
+	return fmt.Sprintf("timestamp field '%s' must be of type time.Time or primitive.DateTime", err.FieldName)
+}
@@ -31,6 +31,16 @@ func TestError(t *testing.T) {
 			Error:          mongo.NewOperationNotSupportedError("Stub"),
 			ExpectedString: "operation 'Stub' not supported",
 		},
+		{
+			Name:           "InvalidNotFoundBehaviorError",
+			Error:          mongo.NewInvalidNotFoundBehaviorError("not-found"),
+			ExpectedString: "invalid not-found behavior 'not-found'",
+		},
+		{
+			Name:           "InvalidIdentifierError",
+			Error:          mongo.NewInvalidIdentifierError("New-UserRepository"),
+			ExpectedString: "invalid identifier 'New-UserRepository'",
+		},
 		{
 			Name:           "BsonTagNotFoundError",
 			Error:          mongo.NewBsonTagNotFoundError("AccessToken"),
@@ -46,6 +56,11 @@ func TestError(t *testing.T) {
 			Error:          mongo.NewUpdateOperatorNotSupportedError(spec.UpdateOperator("STUB")),
 			ExpectedString: "update operator STUB not supported",
 		},
+		{
+			Name:           "TimestampFieldTypeError",
+			Error:          mongo.NewTimestampFieldTypeError("CreatedAt"),
+			ExpectedString: "timestamp field 'CreatedAt' must be of type time.Time or primitive.DateTime",
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -1,25 +1,96 @@
 package mongo
 
 import (
+	"go/token"
 	"go/types"
 	"strconv"
+	"strings"
 
 	"github.com/sunboyy/repogen/internal/codegen"
 	"github.com/sunboyy/repogen/internal/spec"
 )
 
-func (g RepositoryGenerator) generateFindBody(
-	operation spec.FindOperation) (codegen.FunctionBody, error) {
+// NotFoundBehavior is the behavior of the find one methods when no document
+// matches the query. Apart from the predefined behaviors, the value can be an
+// identifier of a user-provided sentinel error (e.g. `ErrNoUser` or
+// `domain.ErrNotFound`) which will be returned instead.
+type NotFoundBehavior string
+
+// predefined not-found behaviors
+const (
+	// NotFoundError returns mongo.ErrNoDocuments from the driver. This is the
+	// default behavior.
+	NotFoundError NotFoundBehavior = "error"
+	// NotFoundNil returns the zero value of the model and nil error.
+	NotFoundNil NotFoundBehavior = "nil"
+	// NotFoundSentinel returns Err<Interface>NotFound that is declared in the
+	// generated code.
+	NotFoundSentinel NotFoundBehavior = "sentinel"
+)
+
+// directivePrefix is the prefix of the doc comment directives of repogen.
+const directivePrefix = "repogen:"
+
+// notFoundDirective is the doc comment directive that overrides the not-found
+// behavior of a method, e.g. `// repogen:notfound=nil`.
+const notFoundDirective = directivePrefix + "notfound="
+
+func (b NotFoundBehavior) validate() error {
+	switch b {
+	case NotFoundError, NotFoundNil, NotFoundSentinel:
+		return nil
+	}
+
+	parts := strings.Split(string(b), ".")
+	if len(parts) > 2 {
+		return NewInvalidNotFoundBehaviorError(string(b))
+	}
+	for _, part := range parts {
+		if !token.IsIdentifier(part) {
+			return NewInvalidNotFoundBehaviorError(string(b))
+		}
+	}
+	return nil
+}
+
+// notFoundBehavior determines the not-found behavior of the method from its
+// doc comment directive, falling back to the behavior of the repository.
+func (g RepositoryGenerator) notFoundBehavior(methodSpec spec.MethodSpec) (NotFoundBehavior, error) {
+	notFound := g.NotFound
+	for _, line := range methodSpec.Doc {
+		text := strings.TrimSpace(strings.TrimPrefix(line, "//"))
+		if value, ok := strings.CutPrefix(text, notFoundDirective); ok {
+			notFound = NotFoundBehavior(value)
+		}
+	}
+
+	if notFound == "" {
+		return NotFoundError, nil
+	}
+	if err := notFound.validate(); err != nil {
+		return "", err
+	}
+	return notFound, nil
+}
+
+func (g RepositoryGenerator) generateFindBody(operation spec.FindOperation,
+	notFound NotFoundBehavior) (codegen.FunctionBody, error) {
 
 	return findBodyGenerator{
 		baseMethodGenerator: g.baseMethodGenerator,
 		operation:           operation,
+		notFound:            notFound,
+		notFoundErr:         g.errNotFound().Name,
 	}.generate()
 }
 
 type findBodyGenerator struct {
 	baseMethodGenerator
 	operation spec.FindOperation
+	notFound  NotFoundBehavior
+	// notFoundErr is the sentinel error returned with NotFoundSentinel
+	// behavior.
+	notFoundErr string
 }
 
 func (g findBodyGenerator) generate() (codegen.FunctionBody, error) {
@@ -34,10 +105,10 @@ func (g findBodyGenerator) generate() (codegen.FunctionBody, error) {
 	}
 
 	if g.operation.Mode == spec.QueryModeOne {
-		return g.generateFindOneBody(querySpec, sortsCode), nil
+		return append(querySpec.Statements(), g.generateFindOneBody(querySpec, sortsCode)...), nil
 	}
 
-	return g.generateFindManyBody(querySpec, sortsCode), nil
+	return append(querySpec.Statements(), g.generateFindManyBody(querySpec, sortsCode)...), nil
 }
 
 func (g findBodyGenerator) generateFindOneBody(querySpec querySpec,
@@ -62,7 +133,7 @@ func (g findBodyGenerator) generateFindOneBody(querySpec querySpec,
 						codegen.NewChainBuilder("r").
 							Chain("collection").
 							Call("FindOne",
-								codegen.Identifier("arg0"),
+								codegen.Identifier(g.param(0)),
 								querySpec.Code(),
 								codegen.Identifier("findOptions"),
 							).
@@ -73,17 +144,75 @@ func (g findBodyGenerator) generateFindOneBody(querySpec querySpec,
 				},
 				errOccurred,
 			},
-			Statements: []codegen.Statement{
-				returnNilErr,
-			},
+			Statements: g.decodeErrorStatements(),
 		},
 		codegen.ReturnStatement{
-			codegen.RawStatement("&entity"),
+			g.returnedEntity(),
 			codegen.Identifier("nil"),
 		},
 	}
 }
 
+// decodeErrorStatements handles the error from decoding the found document
+// which is mongo.ErrNoDocuments if no document matches the query.
+func (g findBodyGenerator) decodeErrorStatements() []codegen.Statement {
+	returnErr := codegen.ReturnStatement{
+		g.zeroModel(),
+		codegen.Identifier("err"),
+	}
+	if g.notFound == NotFoundError {
+		return []codegen.Statement{returnErr}
+	}
+
+	return []codegen.Statement{
+		codegen.IfBlock{
+			Condition: []codegen.Statement{
+				codegen.CallStatement{
+					FuncName: "errors.Is",
+					Params: codegen.StatementList{
+						codegen.Identifier("err"),
+						codegen.Identifier("mongo.ErrNoDocuments"),
+					},
+				},
+			},
+			Statements: []codegen.Statement{
+				codegen.ReturnStatement{
+					g.zeroModel(),
+					g.notFoundError(),
+				},
+			},
+		},
+		returnErr,
+	}
+}
+
+func (g findBodyGenerator) notFoundError() codegen.Statement {
+	switch g.notFound {
+	case NotFoundNil:
+		return codegen.Identifier("nil")
+	case NotFoundSentinel:
+		return codegen.Identifier(g.notFoundErr)
+	default:
+		return codegen.Identifier(string(g.notFound))
+	}
+}
+
+// zeroModel returns the zero value of the returned model for find one
+// operation.
+func (g findBodyGenerator) zeroModel() codegen.Statement {
+	if g.operation.ValueModel {
+		return codegen.RawStatement(codegen.TypeToString(g.targetPkg, g.structModelNamed) + "{}")
+	}
+	return codegen.Identifier("nil")
+}
+
+func (g findBodyGenerator) returnedEntity() codegen.Statement {
+	if g.operation.ValueModel {
+		return codegen.Identifier("entity")
+	}
+	return codegen.RawStatement("&entity")
+}
+
 func (g findBodyGenerator) generateFindManyBody(querySpec querySpec,
 	sortsCode codegen.MapStatement) codegen.FunctionBody {
 
@@ -100,7 +229,7 @@ func (g findBodyGenerator) generateFindManyBody(querySpec querySpec,
 				codegen.NewChainBuilder("r").
 					Chain("collection").
 					Call("Find",
-						codegen.Identifier("arg0"),
+						codegen.Identifier(g.param(0)),
 						querySpec.Code(),
 						codegen.Identifier("findOptions"),
 					).Build(),
@@ -112,7 +241,7 @@ func (g findBodyGenerator) generateFindManyBody(querySpec querySpec,
 			Values: []codegen.Statement{
 				codegen.NewSliceStatement(
 					g.targetPkg,
-					types.NewSlice(types.NewPointer(g.structModelNamed)),
+					types.NewSlice(g.modelType()),
 					[]codegen.Statement{},
 				),
 			},
@@ -124,7 +253,7 @@ func (g findBodyGenerator) generateFindManyBody(querySpec querySpec,
 					Values: codegen.StatementList{
 						codegen.NewChainBuilder("cursor").
 							Call("All",
-								codegen.Identifier("arg0"),
+								codegen.Identifier(g.param(0)),
 								codegen.RawStatement("&entities"),
 							).Build(),
 					},
@@ -142,6 +271,14 @@ func (g findBodyGenerator) generateFindManyBody(querySpec querySpec,
 	}
 }
 
+// modelType returns the type of each returned model.
+func (g findBodyGenerator) modelType() types.Type {
+	if g.operation.ValueModel {
+		return g.structModelNamed
+	}
+	return types.NewPointer(g.structModelNamed)
+}
+
 func (g findBodyGenerator) findManyOptions(
 	sortsCode codegen.MapStatement) codegen.Statement {
 
@@ -165,6 +302,20 @@ func (g findBodyGenerator) generateSortMap() (
 	}
 
 	for _, s := range g.operation.Sorts {
+		if s.Ordering == spec.OrderingTextScore {
+			sortsCode.Pairs = append(sortsCode.Pairs, codegen.MapPair{
+				Key: "score",
+				Value: codegen.MapStatement{
+					Type: "bson.M",
+					Pairs: []codegen.MapPair{{
+						Key:   "$meta",
+						Value: codegen.Identifier(`"textScore"`),
+					}},
+				},
+			})
+			continue
+		}
+
 		bsonFieldReference, err := g.bsonFieldReference(s.FieldReference)
 		if err != nil {
 			return codegen.MapStatement{}, err
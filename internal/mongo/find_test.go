@@ -54,6 +54,273 @@ func TestGenerateMethod_Find(t *testing.T) {
 		return nil, err
 	}
 	return &entity, nil`,
+		},
+		{
+			Name: "find one method returning model by value",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(testutils.TypeUserNamed),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+					ValueModel: true,
+				},
+			},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		return User{}, err
+	}
+	return entity, nil`,
+		},
+		{
+			Name: "find one method returning nil when not found",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+				Doc: []string{
+					"// FindByID finds a user by ID.",
+					"//repogen:notfound=nil",
+				},
+			},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "find one method returning zero model when not found",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(testutils.TypeUserNamed),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+					ValueModel: true,
+				},
+				Doc: []string{
+					"// repogen:notfound=nil",
+				},
+			},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return User{}, nil
+		}
+		return User{}, err
+	}
+	return entity, nil`,
+		},
+		{
+			Name: "find one method returning generated sentinel error when not found",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+				Doc: []string{
+					"// repogen:notfound=sentinel",
+				},
+			},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrUserRepositoryNotFound
+		}
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "find one method returning user-provided sentinel error when not found",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+				Doc: []string{
+					"// repogen:notfound=domain.ErrUserNotFound",
+				},
+			},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "find many method returning models by value",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByGender",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeGenderNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+					ValueModel: true,
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"gender": arg1,
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
 		},
 		{
 			Name: "simple find many method",
@@ -806,9 +1073,9 @@ func TestGenerateMethod_Find(t *testing.T) {
 	return entities, nil`,
 		},
 		{
-			Name: "find with sort ascending",
+			Name: "find with IsNull comparator",
 			MethodSpec: spec.MethodSpec{
-				Name: "FindAllOrderByAge",
+				Name: "FindByReferrerIsNull",
 				Signature: createSignature(
 					[]*types.Var{
 						createTypeVar(testutils.TypeContextNamed),
@@ -820,20 +1087,23 @@ func TestGenerateMethod_Find(t *testing.T) {
 				),
 				Operation: spec.FindOperation{
 					Mode: spec.QueryModeMany,
-					Sorts: []spec.Sort{
-						{
-							FieldReference: spec.FieldReference{
-								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorIsNull,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+								},
+								ParamIndex: 1,
 							},
-							Ordering: spec.OrderingAscending,
 						},
 					},
 				},
 			},
 			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
-		"age": 1,
 	})
 	cursor, err := r.collection.Find(arg0, bson.M{
+		"referrer": nil,
 	}, findOptions)
 	if err != nil {
 		return nil, err
@@ -846,9 +1116,9 @@ func TestGenerateMethod_Find(t *testing.T) {
 	return entities, nil`,
 		},
 		{
-			Name: "find with sort descending",
+			Name: "find with IsNotNull comparator",
 			MethodSpec: spec.MethodSpec{
-				Name: "FindAllOrderByAgeDesc",
+				Name: "FindByReferrerIsNotNull",
 				Signature: createSignature(
 					[]*types.Var{
 						createTypeVar(testutils.TypeContextNamed),
@@ -860,16 +1130,597 @@ func TestGenerateMethod_Find(t *testing.T) {
 				),
 				Operation: spec.FindOperation{
 					Mode: spec.QueryModeMany,
-					Sorts: []spec.Sort{
-						{
-							FieldReference: spec.FieldReference{
-								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
-							},
-							Ordering: spec.OrderingDescending,
-						},
-					},
-				},
-			},
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorIsNotNull,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"referrer": bson.M{
+			"$ne": nil,
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with Before comparator",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByCreatedAtBefore",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeTimeNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorBefore,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"created_at": bson.M{
+			"$lt": arg1,
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with After comparator",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByCreatedAtAfter",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeTimeNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorAfter,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"created_at": bson.M{
+			"$gt": arg1,
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with WithinLast comparator",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByCreatedAtWithinLast",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeDurationNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorWithinLast,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"created_at": bson.M{
+			"$gte": time.Now().Add(-arg1),
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with WithinLast comparator on DateTime field",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByLastLoginWithinLast",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeDurationNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorWithinLast,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "LastLogin"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"last_login": bson.M{
+			"$gte": primitive.NewDateTimeFromTime(time.Now().Add(-arg1)),
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with field comparison",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByUpdatedAtGreaterThanCreatedAt",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorGreaterThan,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "UpdatedAt"),
+								},
+								ParamIndex: 1,
+								ComparedFieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"$expr": bson.M{
+			"$gt": []interface{}{
+				"$updated_at",
+				"$created_at",
+			},
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with negated field comparison",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByUpdatedAtNotGreaterThanCreatedAt",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorGreaterThan,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "UpdatedAt"),
+								},
+								ParamIndex: 1,
+								Negated:    true,
+								ComparedFieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"$expr": bson.M{
+			"$not": bson.M{
+				"$gt": []interface{}{
+					"$updated_at",
+					"$created_at",
+				},
+			},
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with negated Between comparator",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByAgeNotBetween",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeInt),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorBetween,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+								},
+								ParamIndex: 1,
+								Negated:    true,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"age": bson.M{
+			"$not": bson.M{
+				"$gte": arg1,
+				"$lte": arg2,
+			},
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with negated True comparator",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByEnabledNotTrue",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorTrue,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+								},
+								ParamIndex: 1,
+								Negated:    true,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"enabled": bson.M{
+			"$ne": true,
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with TextSearch comparator",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByTextSearchAndGender",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeGenderNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Operator: spec.OperatorAnd,
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorTextSearch,
+								ParamIndex: 1,
+							},
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+								},
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"$and": []bson.M{
+			{
+				"$text": bson.M{
+					"$search": arg1,
+				},
+			},
+			{
+				"gender": arg2,
+			},
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with sort by text score",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByTextSearchOrderByScore",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorTextSearch,
+								ParamIndex: 1,
+							},
+						},
+					},
+					Sorts: []spec.Sort{
+						{
+							Ordering: spec.OrderingTextScore,
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+		"score": bson.M{
+			"$meta": "textScore",
+		},
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+		"$text": bson.M{
+			"$search": arg1,
+		},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with sort ascending",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindAllOrderByAge",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Sorts: []spec.Sort{
+						{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+							},
+							Ordering: spec.OrderingAscending,
+						},
+					},
+				},
+			},
+			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
+		"age": 1,
+	})
+	cursor, err := r.collection.Find(arg0, bson.M{
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find with sort descending",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindAllOrderByAgeDesc",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserStruct))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Sorts: []spec.Sort{
+						{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+							},
+							Ordering: spec.OrderingDescending,
+						},
+					},
+				},
+			},
 			ExpectedBody: `	findOptions := options.Find().SetSort(bson.M{
 		"age": -1,
 	})
@@ -1014,6 +1865,133 @@ func TestGenerateMethod_Find(t *testing.T) {
 	}
 	return entities, nil`,
 		},
+		{
+			Name: "find with query struct parameter",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByCityAndGenderAndAgeBetween",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeUserQueryNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserNamed))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Operator: spec.OperatorAnd,
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+								},
+								ParamIndex:  1,
+								ParamFields: []string{"City"},
+								Optional:    true,
+							},
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+								},
+								ParamIndex:  1,
+								ParamFields: []string{"Gender"},
+							},
+							{
+								Comparator: spec.ComparatorBetween,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+								},
+								ParamIndex:  1,
+								ParamFields: []string{"AgeFrom", "AgeTo"},
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	conditions := []bson.M{
+		{
+			"gender": arg1.Gender,
+		},
+		{
+			"age": bson.M{
+				"$gte": arg1.AgeFrom,
+				"$lte": arg1.AgeTo,
+			},
+		},
+	}
+	if arg1.City != nil {
+		conditions = append(conditions, bson.M{
+			"city": *arg1.City,
+		})
+	}
+	filter := bson.M{}
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+	findOptions := options.Find().SetSort(bson.M{
+	})
+	cursor, err := r.collection.Find(arg0, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	entities := []*User{
+	}
+	if err := cursor.All(arg0, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil`,
+		},
+		{
+			Name: "find one with optional Between predicate",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByAgeBetween",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewPointer(code.TypeInt)),
+						createTypeVar(types.NewPointer(code.TypeInt)),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorBetween,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+								},
+								ParamIndex: 1,
+								Optional:   true,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	filter := bson.M{}
+	if arg1 != nil && arg2 != nil {
+		filter["age"] = bson.M{
+			"$gte": *arg1,
+			"$lte": *arg2,
+		}
+	}
+	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, filter, findOptions).Decode(&entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil`,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -1077,3 +2055,131 @@ func TestGenerateMethod_Find(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateMethod_FindNotFoundBehavior(t *testing.T) {
+	methodSpec := spec.MethodSpec{
+		Name: "FindByID",
+		Signature: createSignature(
+			[]*types.Var{
+				createTypeVar(testutils.TypeContextNamed),
+				createTypeVar(testutils.TypeObjectIDNamed),
+			},
+			[]*types.Var{
+				createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+				createTypeVar(code.TypeError),
+			},
+		),
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{
+				Predicates: []spec.Predicate{
+					{
+						Comparator: spec.ComparatorEqual,
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+						},
+						ParamIndex: 1,
+					},
+				},
+			},
+		},
+	}
+	overriddenMethodSpec := methodSpec
+	overriddenMethodSpec.Doc = []string{"// repogen:notfound=error"}
+
+	testTable := []struct {
+		Name         string
+		NotFound     mongo.NotFoundBehavior
+		MethodSpec   spec.MethodSpec
+		ExpectedBody string
+	}{
+		{
+			Name:       "repository not-found behavior",
+			NotFound:   mongo.NotFoundSentinel,
+			MethodSpec: methodSpec,
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrUserRepositoryNotFound
+		}
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name:       "method directive overrides repository not-found behavior",
+			NotFound:   mongo.NotFoundSentinel,
+			MethodSpec: overriddenMethodSpec,
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+			generator.NotFound = testCase.NotFound
+
+			actual, err := generator.GenerateMethod(testCase.MethodSpec)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutils.ExpectMultiLineString(testCase.ExpectedBody, actual.Body.Code()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGenerateMethod_FindVariadic(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	methodSpec := spec.MethodSpec{
+		Name: "FindByGenderIn",
+		Signature: types.NewSignatureType(nil, nil, nil,
+			types.NewTuple(
+				createTypeVar(testutils.TypeContextNamed),
+				createTypeVar(types.NewSlice(testutils.TypeGenderNamed)),
+			),
+			types.NewTuple(
+				createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserNamed))),
+				createTypeVar(code.TypeError),
+			),
+			true,
+		),
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Predicates: []spec.Predicate{
+					{
+						Comparator: spec.ComparatorIn,
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						},
+						ParamIndex: 1,
+					},
+				},
+			},
+		},
+	}
+
+	actual, err := generator.GenerateMethod(methodSpec)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !actual.Variadic {
+		t.Error("expected variadic method, got non-variadic method")
+	}
+}
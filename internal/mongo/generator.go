@@ -2,8 +2,13 @@ package mongo
 
 import (
 	"fmt"
+	"go/scanner"
 	"go/token"
 	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/sunboyy/repogen/internal/code"
 	"github.com/sunboyy/repogen/internal/codegen"
@@ -26,6 +31,24 @@ func NewGenerator(targetPkg *types.Package, structModelNamed *types.Named, inter
 type RepositoryGenerator struct {
 	baseMethodGenerator
 	InterfaceName string
+	// NotFound is the default behavior of the find one methods when no
+	// document matches the query. Each method can override it with
+	// `repogen:notfound=` doc comment directive.
+	NotFound NotFoundBehavior
+	// WrapErrors wraps the driver errors returned by the generated methods
+	// with the repository and method name, and classifies them into the
+	// generated sentinel errors.
+	WrapErrors bool
+	// ImplName is the name of the implementation struct. It defaults to the
+	// interface name suffixed with Mongo.
+	ImplName string
+	// UnexportedImpl unexports the implementation struct by lowercasing the
+	// first letter of its name. The constructor then returns the repository
+	// interface.
+	UnexportedImpl bool
+	// ConstructorName is the name of the constructor of the implementation
+	// struct. It defaults to the interface name prefixed with New.
+	ConstructorName string
 }
 
 // Imports returns necessary imports for the mongo repository implementation.
@@ -33,6 +56,7 @@ func (g RepositoryGenerator) Imports() [][]codegen.Import {
 	return [][]codegen.Import{
 		{
 			{Path: "context"},
+			{Path: "time"},
 		},
 		{
 			{Path: "go.mongodb.org/mongo-driver/bson"},
@@ -58,16 +82,27 @@ func (g RepositoryGenerator) GenerateStruct() codegen.StructBuilder {
 }
 
 // GenerateConstructor creates codegen.FunctionBuilder of a constructor for
-// mongo repository implementation struct.
-func (g RepositoryGenerator) GenerateConstructor() (codegen.FunctionBuilder, error) {
+// mongo repository implementation struct. The constructor returns the
+// repository interface type if the implementation struct is unexported, so
+// that its exported signature does not expose an unexported type.
+func (g RepositoryGenerator) GenerateConstructor(interfaceType types.Type) (codegen.FunctionBuilder, error) {
+	for _, name := range []string{g.repoImplStructName(), g.constructorName()} {
+		if !token.IsIdentifier(name) {
+			return codegen.FunctionBuilder{}, NewInvalidIdentifierError(name)
+		}
+	}
+
+	var returnType types.Type = types.NewPointer(types.NewNamed(
+		types.NewTypeName(token.NoPos, nil, g.repoImplStructName(), nil), nil, nil))
+	if g.UnexportedImpl {
+		returnType = interfaceType
+	}
+
 	return codegen.FunctionBuilder{
-		Pkg:    g.targetPkg,
-		Name:   "New" + g.InterfaceName,
-		Params: types.NewTuple(types.NewVar(token.NoPos, nil, "collection", types.NewPointer(mongoCollectionType))),
-		Returns: []types.Type{
-			types.NewPointer(types.NewNamed(
-				types.NewTypeName(token.NoPos, nil, g.repoImplStructName(), nil), nil, nil)),
-		},
+		Pkg:     g.targetPkg,
+		Name:    g.constructorName(),
+		Params:  types.NewTuple(types.NewVar(token.NoPos, nil, "collection", types.NewPointer(mongoCollectionType))),
+		Returns: []types.Type{returnType},
 		Body: codegen.FunctionBody{
 			codegen.ReturnStatement{
 				codegen.StructStatement{
@@ -82,12 +117,121 @@ func (g RepositoryGenerator) GenerateConstructor() (codegen.FunctionBuilder, err
 	}, nil
 }
 
+// GenerateInterfaceAssertion creates codegen.VarBuilder that asserts the
+// implementation struct satisfies the repository interface at compile time.
+func (g RepositoryGenerator) GenerateInterfaceAssertion(interfaceType types.Type) codegen.VarBuilder {
+	return codegen.VarBuilder{
+		Pkg: g.targetPkg,
+		Vars: []codegen.Var{
+			{
+				Name:  "_",
+				Type:  interfaceType,
+				Value: codegen.RawStatement(fmt.Sprintf("(*%s)(nil)", g.repoImplStructName())),
+			},
+		},
+	}
+}
+
+// generatedError is a sentinel error declared in the generated code so that
+// the callers can check the errors returned by the repository with errors.Is.
+// Its name is prefixed with the repository interface name so that the
+// repositories generated into the same package do not redeclare it.
+type generatedError struct {
+	Name    string
+	Message string
+}
+
+// errInsertedIDTypeMismatch is returned when the database generates an ID of
+// a type other than the ID field of the model.
+func (g RepositoryGenerator) errInsertedIDTypeMismatch() generatedError {
+	return generatedError{
+		Name:    "Err" + g.InterfaceName + "InsertedIDTypeMismatch",
+		Message: "inserted ID type mismatch",
+	}
+}
+
+// errNotFound is returned when no document matches the query.
+func (g RepositoryGenerator) errNotFound() generatedError {
+	return generatedError{
+		Name:    "Err" + g.InterfaceName + "NotFound",
+		Message: "not found",
+	}
+}
+
+// GenerateErrors creates codegen.VarBuilder of the sentinel errors returned by
+// the generated methods. Each error is declared once in the order of its
+// first usage, after the errors used for classification if the errors are
+// wrapped.
+func (g RepositoryGenerator) GenerateErrors(methodSpecs []spec.MethodSpec) codegen.VarBuilder {
+	var requiredErrors []generatedError
+	if g.WrapErrors {
+		for _, classification := range g.errorClassifications() {
+			requiredErrors = append(requiredErrors, classification.Error)
+		}
+	}
+	for _, methodSpec := range methodSpecs {
+		requiredErrors = append(requiredErrors, g.requiredErrors(methodSpec)...)
+	}
+
+	var builder codegen.VarBuilder
+	declared := make(map[string]bool)
+	for _, generatedErr := range requiredErrors {
+		if declared[generatedErr.Name] {
+			continue
+		}
+		declared[generatedErr.Name] = true
+
+		builder.Vars = append(builder.Vars, codegen.Var{
+			Name: generatedErr.Name,
+			Value: codegen.CallStatement{
+				FuncName: "errors.New",
+				Params: codegen.StatementList{
+					codegen.Identifier(strconv.Quote(generatedErr.Message)),
+				},
+			},
+		})
+	}
+	return builder
+}
+
+func (g RepositoryGenerator) requiredErrors(methodSpec spec.MethodSpec) []generatedError {
+	switch operation := methodSpec.Operation.(type) {
+	case spec.InsertOperation:
+		if operation.IDType != nil || operation.ReturnModel {
+			return []generatedError{g.errInsertedIDTypeMismatch()}
+		}
+	case spec.UpdateOperation:
+		if operation.UpsertedIDType != nil {
+			return []generatedError{g.errInsertedIDTypeMismatch()}
+		}
+	case spec.FindOperation:
+		notFound, err := g.notFoundBehavior(methodSpec)
+		if err == nil && operation.Mode == spec.QueryModeOne && notFound == NotFoundSentinel {
+			return []generatedError{g.errNotFound()}
+		}
+	}
+	return nil
+}
+
 // GenerateMethod creates codegen.MethodBuilder of repository method from the
 // provided method specification.
 func (g RepositoryGenerator) GenerateMethod(methodSpec spec.MethodSpec) (codegen.MethodBuilder, error) {
+	// the body is first generated with the fallback parameter names to find
+	// the identifiers that the parameter names must not shadow
+	implementation, err := g.generateMethodBody(methodSpec)
+	if err != nil {
+		return codegen.MethodBuilder{}, err
+	}
+
+	g.paramNames = paramNames(methodSpec.Signature.Params(), implementation)
+	implementation, err = g.generateMethodBody(methodSpec)
+	if err != nil {
+		return codegen.MethodBuilder{}, err
+	}
+
 	var paramVars []*types.Var
 	for i := 0; i < methodSpec.Signature.Params().Len(); i++ {
-		param := types.NewVar(token.NoPos, nil, fmt.Sprintf("arg%d", i),
+		param := types.NewVar(token.NoPos, nil, g.param(i),
 			methodSpec.Signature.Params().At(i).Type())
 		paramVars = append(paramVars, param)
 	}
@@ -97,33 +241,104 @@ func (g RepositoryGenerator) GenerateMethod(methodSpec spec.MethodSpec) (codegen
 		returns = append(returns, methodSpec.Signature.Results().At(i).Type())
 	}
 
-	implementation, err := g.generateMethodImplementation(methodSpec)
-	if err != nil {
-		return codegen.MethodBuilder{}, err
-	}
-
 	return codegen.MethodBuilder{
 		Pkg: g.targetPkg,
+		Doc: implementationDoc(methodSpec.Doc),
 		Receiver: codegen.MethodReceiver{
 			Name:     "r",
 			TypeName: g.repoImplStructName(),
 			Pointer:  true,
 		},
-		Name:    methodSpec.Name,
-		Params:  types.NewTuple(paramVars...),
-		Returns: returns,
-		Body:    implementation,
+		Name:     methodSpec.Name,
+		Params:   types.NewTuple(paramVars...),
+		Variadic: methodSpec.Signature.Variadic(),
+		Returns:  returns,
+		Body:     implementation,
 	}, nil
 }
 
+func (g RepositoryGenerator) generateMethodBody(methodSpec spec.MethodSpec) (codegen.FunctionBody, error) {
+	implementation, err := g.generateMethodImplementation(methodSpec)
+	if err != nil {
+		return nil, err
+	}
+	if g.WrapErrors {
+		implementation = wrapReturnedErrors(implementation, g.wrapPrefix(methodSpec.Name),
+			g.wrapError(methodSpec.Name))
+	}
+	return implementation, nil
+}
+
+// paramNames returns the names of the parameters of the interface method. A
+// parameter falls back to argN if it is unnamed or its name is used by the
+// body generated with the fallback names, including the receiver and the
+// fallback names themselves.
+func paramNames(params *types.Tuple, body codegen.FunctionBody) []string {
+	usedNames := bodyIdentifiers(body)
+	names := make([]string, params.Len())
+	for i := range names {
+		names[i] = fmt.Sprintf("arg%d", i)
+		usedNames[names[i]] = true
+	}
+
+	for i := range names {
+		name := params.At(i).Name()
+		if name != "" && name != "_" && !usedNames[name] {
+			names[i] = name
+		}
+	}
+	return names
+}
+
+// bodyIdentifiers collects the identifiers that appear in the function body.
+func bodyIdentifiers(body codegen.FunctionBody) map[string]bool {
+	src := []byte(body.Code())
+	fset := token.NewFileSet()
+
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", fset.Base(), len(src)), src, nil, 0)
+
+	identifiers := make(map[string]bool)
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return identifiers
+		}
+		if tok == token.IDENT {
+			identifiers[lit] = true
+		}
+	}
+}
+
+// implementationDoc returns the doc comment of the interface method to be
+// copied onto its implementation, leaving out the repogen directives.
+func implementationDoc(doc []string) []string {
+	var lines []string
+	for _, line := range doc {
+		text := strings.TrimSpace(strings.TrimPrefix(line, "//"))
+		if !strings.HasPrefix(text, directivePrefix) {
+			lines = append(lines, line)
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "//" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
 func (g RepositoryGenerator) generateMethodImplementation(
 	methodSpec spec.MethodSpec) (codegen.FunctionBody, error) {
 
 	switch operation := methodSpec.Operation.(type) {
 	case spec.InsertOperation:
-		return g.generateInsertBody(operation), nil
+		return g.generateInsertBody(operation)
 	case spec.FindOperation:
-		return g.generateFindBody(operation)
+		notFound, err := g.notFoundBehavior(methodSpec)
+		if err != nil {
+			return nil, err
+		}
+		return g.generateFindBody(operation, notFound)
 	case spec.UpdateOperation:
 		return g.generateUpdateBody(operation)
 	case spec.DeleteOperation:
@@ -136,5 +351,20 @@ func (g RepositoryGenerator) generateMethodImplementation(
 }
 
 func (g RepositoryGenerator) repoImplStructName() string {
-	return g.InterfaceName + "Mongo"
+	name := g.ImplName
+	if name == "" {
+		name = g.InterfaceName + "Mongo"
+	}
+	if g.UnexportedImpl {
+		first, size := utf8.DecodeRuneInString(name)
+		return string(unicode.ToLower(first)) + name[size:]
+	}
+	return name
+}
+
+func (g RepositoryGenerator) constructorName() string {
+	if g.ConstructorName == "" {
+		return "New" + g.InterfaceName
+	}
+	return g.ConstructorName
 }
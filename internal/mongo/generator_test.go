@@ -19,6 +19,7 @@ func TestImports(t *testing.T) {
 	expected := [][]codegen.Import{
 		{
 			{Path: "context"},
+			{Path: "time"},
 		},
 		{
 			{Path: "go.mongodb.org/mongo-driver/bson"},
@@ -66,8 +67,49 @@ func TestGenerateStruct(t *testing.T) {
 	}
 }
 
+func TestGenerateStruct_Naming(t *testing.T) {
+	testTable := []struct {
+		Name           string
+		ImplName       string
+		UnexportedImpl bool
+		ExpectedName   string
+	}{
+		{
+			Name:           "unexported default name",
+			UnexportedImpl: true,
+			ExpectedName:   "userRepositoryMongo",
+		},
+		{
+			Name:         "custom name",
+			ImplName:     "MongoUserRepository",
+			ExpectedName: "MongoUserRepository",
+		},
+		{
+			Name:           "unexported custom name",
+			ImplName:       "MongoUserRepository",
+			UnexportedImpl: true,
+			ExpectedName:   "mongoUserRepository",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+			generator.ImplName = testCase.ImplName
+			generator.UnexportedImpl = testCase.UnexportedImpl
+
+			actual := generator.GenerateStruct()
+
+			if testCase.ExpectedName != actual.Name {
+				t.Errorf("incorrect struct name: expected %s, got %s", testCase.ExpectedName, actual.Name)
+			}
+		})
+	}
+}
+
 func TestGenerateConstructor(t *testing.T) {
 	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	intfType := types.NewNamed(types.NewTypeName(token.NoPos, testutils.Pkg, "UserRepository", nil), nil, nil)
 	expected := codegen.FunctionBuilder{
 		Name: "NewUserRepository",
 		Params: types.NewTuple(
@@ -89,7 +131,7 @@ func TestGenerateConstructor(t *testing.T) {
 		},
 	}
 
-	actual, err := generator.GenerateConstructor()
+	actual, err := generator.GenerateConstructor(intfType)
 
 	if err != nil {
 		t.Fatal(err)
@@ -133,6 +175,117 @@ func TestGenerateConstructor(t *testing.T) {
 	}
 }
 
+func TestGenerateErrors(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	insertOneIDSpec := spec.MethodSpec{
+		Name: "InsertOneID",
+		Operation: spec.InsertOperation{
+			Mode:   spec.QueryModeOne,
+			IDType: testutils.TypeObjectIDNamed,
+		},
+	}
+	insertManyIDsSpec := spec.MethodSpec{
+		Name: "InsertManyIDs",
+		Operation: spec.InsertOperation{
+			Mode:   spec.QueryModeMany,
+			IDType: testutils.TypeObjectIDNamed,
+		},
+	}
+	insertOneSpec := spec.MethodSpec{
+		Name: "InsertOne",
+		Operation: spec.InsertOperation{
+			Mode: spec.QueryModeOne,
+		},
+	}
+	upsertIDSpec := spec.MethodSpec{
+		Name: "UpsertCityByID",
+		Operation: spec.UpdateOperation{
+			Mode:           spec.QueryModeOne,
+			Upsert:         true,
+			UpsertedIDType: testutils.TypeObjectIDNamed,
+		},
+	}
+	expected := codegen.VarBuilder{
+		Vars: []codegen.Var{
+			{
+				Name: "ErrUserRepositoryInsertedIDTypeMismatch",
+				Value: codegen.CallStatement{
+					FuncName: "errors.New",
+					Params: codegen.StatementList{
+						codegen.Identifier(`"inserted ID type mismatch"`),
+					},
+				},
+			},
+		},
+	}
+
+	actual := generator.GenerateErrors([]spec.MethodSpec{insertOneSpec, insertOneIDSpec, insertManyIDsSpec,
+		upsertIDSpec})
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("incorrect errors: expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestGenerateErrors_NotFound(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	generator.NotFound = mongo.NotFoundSentinel
+	findOneSpec := spec.MethodSpec{
+		Name: "FindByID",
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeOne,
+		},
+	}
+	findOneNilSpec := spec.MethodSpec{
+		Name: "FindByPhoneNumber",
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeOne,
+		},
+		Doc: []string{"// repogen:notfound=nil"},
+	}
+	findManySpec := spec.MethodSpec{
+		Name: "FindByCity",
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeMany,
+		},
+	}
+	expected := codegen.VarBuilder{
+		Vars: []codegen.Var{
+			{
+				Name: "ErrUserRepositoryNotFound",
+				Value: codegen.CallStatement{
+					FuncName: "errors.New",
+					Params: codegen.StatementList{
+						codegen.Identifier(`"not found"`),
+					},
+				},
+			},
+		},
+	}
+
+	actual := generator.GenerateErrors([]spec.MethodSpec{findManySpec, findOneNilSpec, findOneSpec, findOneSpec})
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("incorrect errors: expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestGenerateErrors_NoError(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	insertOneSpec := spec.MethodSpec{
+		Name: "InsertOne",
+		Operation: spec.InsertOperation{
+			Mode: spec.QueryModeOne,
+		},
+	}
+
+	actual := generator.GenerateErrors([]spec.MethodSpec{insertOneSpec})
+
+	if len(actual.Vars) != 0 {
+		t.Errorf("expected no errors, got %+v", actual.Vars)
+	}
+}
+
 type GenerateMethodTestCase struct {
 	Name         string
 	MethodSpec   spec.MethodSpec
@@ -145,6 +298,357 @@ type GenerateMethodInvalidTestCase struct {
 	ExpectedError error
 }
 
+func TestGenerateMethod_ParamNamesAndDoc(t *testing.T) {
+	findByIDOperation := spec.FindOperation{
+		Mode: spec.QueryModeOne,
+		Query: spec.QuerySpec{
+			Predicates: []spec.Predicate{
+				{
+					Comparator: spec.ComparatorEqual,
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					ParamIndex: 1,
+				},
+			},
+		},
+	}
+	findByIDReturns := []*types.Var{
+		createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+		createTypeVar(code.TypeError),
+	}
+
+	testTable := []struct {
+		Name               string
+		MethodSpec         spec.MethodSpec
+		ExpectedParamNames []string
+		ExpectedDoc        []string
+		ExpectedBody       string
+	}{
+		{
+			Name: "named parameters",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						types.NewVar(token.NoPos, nil, "ctx", testutils.TypeContextNamed),
+						types.NewVar(token.NoPos, nil, "id", testutils.TypeObjectIDNamed),
+					},
+					findByIDReturns,
+				),
+				Operation: findByIDOperation,
+				Doc: []string{
+					"// FindByID returns the user with the given ID.",
+					"//",
+					"// repogen:notfound=nil",
+				},
+			},
+			ExpectedParamNames: []string{"ctx", "id"},
+			ExpectedDoc:        []string{"// FindByID returns the user with the given ID."},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "conflicting and blank parameter names",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						types.NewVar(token.NoPos, nil, "_", testutils.TypeContextNamed),
+						types.NewVar(token.NoPos, nil, "entity", testutils.TypeObjectIDNamed),
+					},
+					findByIDReturns,
+				),
+				Operation: findByIDOperation,
+			},
+			ExpectedParamNames: []string{"arg0", "arg1"},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "parameter named after fallback name of another parameter",
+			MethodSpec: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						types.NewVar(token.NoPos, nil, "ctx", testutils.TypeContextNamed),
+						types.NewVar(token.NoPos, nil, "arg0", testutils.TypeObjectIDNamed),
+					},
+					findByIDReturns,
+				),
+				Operation: findByIDOperation,
+			},
+			ExpectedParamNames: []string{"ctx", "arg1"},
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(ctx, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "patch parameter",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdatePatchByCity",
+				Signature: createSignature(
+					[]*types.Var{
+						types.NewVar(token.NoPos, nil, "ctx", testutils.TypeContextNamed),
+						types.NewVar(token.NoPos, nil, "patch", testutils.TypeUserPatchNamed),
+						types.NewVar(token.NoPos, nil, "city", code.TypeString),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdatePatch{
+						spec.UpdatePatchField{
+							Name: "Name",
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
+							},
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+								},
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedParamNames: []string{"ctx", "patch", "city"},
+			ExpectedBody: `	patchFields := bson.M{}
+	if patch.Name != nil {
+		patchFields["name"] = *patch.Name
+	}
+	if len(patchFields) == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{
+			"city": city,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return int(count), nil
+	}
+	result, err := r.collection.UpdateMany(ctx, bson.M{
+		"city": city,
+	}, bson.M{
+		"$set": patchFields,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil`,
+		},
+		{
+			Name: "upsert by ID",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpsertCityByID",
+				Signature: createSignature(
+					[]*types.Var{
+						types.NewVar(token.NoPos, nil, "ctx", testutils.TypeContextNamed),
+						types.NewVar(token.NoPos, nil, "city", code.TypeString),
+						types.NewVar(token.NoPos, nil, "id", testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(testutils.TypeObjectIDNamed),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 2,
+							},
+						},
+					},
+					Upsert:         true,
+					UpsertedIDType: testutils.TypeObjectIDNamed,
+				},
+			},
+			ExpectedParamNames: []string{"ctx", "city", "id"},
+			ExpectedBody: `	var upsertedID primitive.ObjectID
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.M{
+		"$set": bson.M{
+			"city": city,
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, upsertedID, err
+	}
+	if result.UpsertedID != nil {
+		upserted, ok := result.UpsertedID.(primitive.ObjectID)
+		if !ok {
+			return false, upsertedID, fmt.Errorf("%w: %T", ErrUserRepositoryInsertedIDTypeMismatch, result.UpsertedID)
+		}
+		upsertedID = upserted
+	}
+	return result.MatchedCount > 0 || result.UpsertedCount > 0, upsertedID, nil`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+
+			actual, err := generator.GenerateMethod(testCase.MethodSpec)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actualParamNames []string
+			for i := 0; i < actual.Params.Len(); i++ {
+				actualParamNames = append(actualParamNames, actual.Params.At(i).Name())
+			}
+			if !reflect.DeepEqual(testCase.ExpectedParamNames, actualParamNames) {
+				t.Errorf("incorrect param names: expected %v, got %v", testCase.ExpectedParamNames,
+					actualParamNames)
+			}
+			if !reflect.DeepEqual(testCase.ExpectedDoc, actual.Doc) {
+				t.Errorf("incorrect doc: expected %v, got %v", testCase.ExpectedDoc, actual.Doc)
+			}
+			if err := testutils.ExpectMultiLineString(testCase.ExpectedBody, actual.Body.Code()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGenerateConstructor_Naming(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	generator.UnexportedImpl = true
+	generator.ConstructorName = "NewMongoUserRepository"
+	intfType := types.NewNamed(types.NewTypeName(token.NoPos, testutils.Pkg, "UserRepository", nil), nil, nil)
+	expectedBody := codegen.FunctionBody{
+		codegen.ReturnStatement{
+			codegen.StructStatement{
+				Type: "&userRepositoryMongo",
+				Pairs: []codegen.StructFieldPair{{
+					Key:   "collection",
+					Value: codegen.Identifier("collection"),
+				}},
+			},
+		},
+	}
+
+	actual, err := generator.GenerateConstructor(intfType)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.Name != "NewMongoUserRepository" {
+		t.Errorf("incorrect function name: expected NewMongoUserRepository, got %s", actual.Name)
+	}
+	if len(actual.Returns) != 1 || actual.Returns[0] != intfType {
+		t.Errorf("incorrect function returns: expected [%s], got %v", intfType, actual.Returns)
+	}
+	if !reflect.DeepEqual(expectedBody, actual.Body) {
+		t.Errorf("incorrect function body: expected %+v got %+v", expectedBody, actual.Body)
+	}
+}
+
+func TestGenerateConstructor_InvalidName(t *testing.T) {
+	testTable := []struct {
+		Name            string
+		ImplName        string
+		ConstructorName string
+		ExpectedError   error
+	}{
+		{
+			Name:          "invalid struct name",
+			ImplName:      "User Repository",
+			ExpectedError: mongo.NewInvalidIdentifierError("User Repository"),
+		},
+		{
+			Name:            "invalid constructor name",
+			ConstructorName: "New-UserRepository",
+			ExpectedError:   mongo.NewInvalidIdentifierError("New-UserRepository"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+			generator.ImplName = testCase.ImplName
+			generator.ConstructorName = testCase.ConstructorName
+
+			_, err := generator.GenerateConstructor(nil)
+
+			if !errors.Is(err, testCase.ExpectedError) {
+				t.Errorf("\nExpected = %+v\nReceived = %+v", testCase.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestGenerateInterfaceAssertion(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	generator.UnexportedImpl = true
+	intfType := types.NewNamed(types.NewTypeName(token.NoPos, testutils.Pkg, "UserRepository", nil), nil, nil)
+	expected := codegen.VarBuilder{
+		Pkg: testutils.Pkg,
+		Vars: []codegen.Var{
+			{
+				Name:  "_",
+				Type:  intfType,
+				Value: codegen.RawStatement("(*userRepositoryMongo)(nil)"),
+			},
+		},
+	}
+
+	actual := generator.GenerateInterfaceAssertion(intfType)
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("incorrect interface assertion: expected %+v, got %+v", expected, actual)
+	}
+}
+
 type StubOperation struct {
 }
 
@@ -172,6 +676,84 @@ func TestGenerateMethod_Invalid(t *testing.T) {
 			},
 			ExpectedError: mongo.NewOperationNotSupportedError("Stub"),
 		},
+		{
+			Name: "invalid not-found behavior",
+			Method: spec.MethodSpec{
+				Name: "FindByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.FindOperation{
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								Comparator: spec.ComparatorEqual,
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+				Doc: []string{"// repogen:notfound=not-found"},
+			},
+			ExpectedError: mongo.NewInvalidNotFoundBehaviorError("not-found"),
+		},
+		{
+			Name: "optional predicate with filtered positional operator",
+			Method: spec.MethodSpec{
+				Name: "UpdateConsentHistoryElemValueByConsentHistoryValue",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeBool),
+						createTypeVar(types.NewPointer(code.TypeBool)),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+								testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+							},
+							ParamIndex:      1,
+							Operator:        spec.UpdateOperatorSet,
+							Positional:      spec.PositionalFiltered,
+							PositionalDepth: 1,
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+									testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+								Optional:   true,
+							},
+						},
+					},
+				},
+			},
+			ExpectedError: mongo.ErrOptionalArrayFilter,
+		},
 		{
 			Name: "bson tag not found in query",
 			Method: spec.MethodSpec{
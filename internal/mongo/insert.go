@@ -1,65 +1,168 @@
 package mongo
 
 import (
+	"fmt"
 	"go/types"
 
+	"github.com/sunboyy/repogen/internal/code"
 	"github.com/sunboyy/repogen/internal/codegen"
 	"github.com/sunboyy/repogen/internal/spec"
 )
 
 func (g RepositoryGenerator) generateInsertBody(
-	operation spec.InsertOperation) codegen.FunctionBody {
+	operation spec.InsertOperation) (codegen.FunctionBody, error) {
+
+	timestampFields, err := g.timestampFields(timestampCreatedAt, timestampUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var idField code.StructField
+	if operation.ReturnModel {
+		idField, err = g.idField()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	generator := insertBodyGenerator{
+		baseMethodGenerator: g.baseMethodGenerator,
+		operation:           operation,
+		timestampFields:     timestampFields,
+		idField:             idField,
+		idTypeMismatchErr:   g.errInsertedIDTypeMismatch().Name,
+	}
 
 	if operation.Mode == spec.QueryModeOne {
-		return g.generateInsertOneBody()
+		return generator.generateInsertOneBody(), nil
 	}
-	return g.generateInsertManyBody()
+	return generator.generateInsertManyBody(), nil
 }
 
-func (g RepositoryGenerator) generateInsertOneBody() codegen.FunctionBody {
-	return codegen.FunctionBody{
-		codegen.DeclAssignStatement{
-			Vars: []string{"result", "err"},
-			Values: codegen.StatementList{
-				codegen.NewChainBuilder("r").
-					Chain("collection").
-					Call("InsertOne",
-						codegen.Identifier("arg0"),
-						codegen.Identifier("arg1"),
-					).Build(),
-			},
+type insertBodyGenerator struct {
+	baseMethodGenerator
+	operation       spec.InsertOperation
+	timestampFields []timestampField
+	// idField is the field that the inserted IDs are written to when the
+	// inserted models are returned.
+	idField code.StructField
+	// idTypeMismatchErr is the sentinel error returned when the inserted ID
+	// is not of the expected type.
+	idTypeMismatchErr string
+}
+
+func (g insertBodyGenerator) generateInsertOneBody() codegen.FunctionBody {
+	var body codegen.FunctionBody
+	if len(g.timestampFields) > 0 {
+		body = append(body, declareNow)
+		body = append(body, timestampAssignStatements(g.param(1), g.timestampFields)...)
+	}
+
+	// the zero value of the typed inserted ID is returned when an error
+	// occurs
+	if g.operation.IDType != nil {
+		body = append(body, codegen.NewDeclStatement(g.targetPkg, "insertedID", g.operation.IDType))
+	}
+
+	body = append(body, codegen.DeclAssignStatement{
+		Vars: []string{"result", "err"},
+		Values: codegen.StatementList{
+			codegen.NewChainBuilder("r").
+				Chain("collection").
+				Call("InsertOne",
+					codegen.Identifier(g.param(0)),
+					codegen.Identifier(g.param(1)),
+				).Build(),
 		},
-		ifErrReturnNilErr,
-		codegen.ReturnStatement{
-			codegen.NewChainBuilder("result").Chain("InsertedID").Build(),
+	})
+
+	switch {
+	case g.operation.ReturnModel:
+		body = append(body, ifErrReturnNilErr)
+		body = append(body, g.assertInsertedID("result.InsertedID", g.idField.Var.Type(),
+			codegen.Identifier("nil"))...)
+		return append(body,
+			codegen.AssignStatement{
+				Vars:   []string{g.param(1) + "." + g.idField.Var.Name()},
+				Values: codegen.StatementList{codegen.Identifier("insertedID")},
+			},
+			codegen.ReturnStatement{
+				codegen.Identifier(g.param(1)),
+				codegen.Identifier("nil"),
+			},
+		)
+
+	case g.operation.IDType != nil:
+		body = append(body, codegen.IfBlock{
+			Condition: []codegen.Statement{
+				errOccurred,
+			},
+			Statements: []codegen.Statement{
+				codegen.ReturnStatement{
+					codegen.Identifier("insertedID"),
+					codegen.Identifier("err"),
+				},
+			},
+		})
+		body = append(body, g.assertInsertedID("result.InsertedID", g.operation.IDType,
+			codegen.Identifier("insertedID"))...)
+		return append(body, codegen.ReturnStatement{
+			codegen.Identifier("insertedID"),
 			codegen.Identifier("nil"),
-		},
+		})
+
+	default:
+		return append(body,
+			ifErrReturnNilErr,
+			codegen.ReturnStatement{
+				codegen.NewChainBuilder("result").Chain("InsertedID").Build(),
+				codegen.Identifier("nil"),
+			},
+		)
 	}
 }
 
-func (g RepositoryGenerator) generateInsertManyBody() codegen.FunctionBody {
-	return codegen.FunctionBody{
+func (g insertBodyGenerator) generateInsertManyBody() codegen.FunctionBody {
+	var body codegen.FunctionBody
+	if len(g.timestampFields) > 0 {
+		body = append(body, declareNow)
+	}
+
+	// the models given by value are referenced by their indices so that the
+	// timestamps are written to the given slice
+	loopHeader := "for _, model := range " + g.param(1)
+	var loopStatements []codegen.Statement
+	if g.operation.ValueModel {
+		loopHeader = "for i := range " + g.param(1)
+		loopStatements = append(loopStatements, codegen.DeclAssignStatement{
+			Vars:   []string{"model"},
+			Values: codegen.StatementList{codegen.RawStatement("&" + g.param(1) + "[i]")},
+		})
+	}
+
+	loopStatements = append(loopStatements, timestampAssignStatements("model", g.timestampFields)...)
+	loopStatements = append(loopStatements, codegen.AssignStatement{
+		Vars: []string{"entities"},
+		Values: codegen.StatementList{
+			codegen.CallStatement{
+				FuncName: "append",
+				Params: codegen.StatementList{
+					codegen.Identifier("entities"),
+					codegen.Identifier("model"),
+				},
+			},
+		},
+	})
+
+	body = append(body,
 		codegen.NewDeclStatement(
 			g.targetPkg,
 			"entities",
 			types.NewSlice(types.NewInterfaceType(nil, nil)),
 		),
 		codegen.RawBlock{
-			Header: []string{"for _, model := range arg1"},
-			Statements: []codegen.Statement{
-				codegen.AssignStatement{
-					Vars: []string{"entities"},
-					Values: codegen.StatementList{
-						codegen.CallStatement{
-							FuncName: "append",
-							Params: codegen.StatementList{
-								codegen.Identifier("entities"),
-								codegen.Identifier("model"),
-							},
-						},
-					},
-				},
-			},
+			Header:     []string{loopHeader},
+			Statements: loopStatements,
 		},
 		codegen.DeclAssignStatement{
 			Vars: []string{"result", "err"},
@@ -67,15 +170,93 @@ func (g RepositoryGenerator) generateInsertManyBody() codegen.FunctionBody {
 				codegen.NewChainBuilder("r").
 					Chain("collection").
 					Call("InsertMany",
-						codegen.Identifier("arg0"),
+						codegen.Identifier(g.param(0)),
 						codegen.Identifier("entities"),
 					).Build(),
 			},
 		},
 		ifErrReturnNilErr,
-		codegen.ReturnStatement{
+	)
+
+	switch {
+	case g.operation.ReturnModel:
+		idStatements := g.assertInsertedID("id", g.idField.Var.Type(), codegen.Identifier("nil"))
+		idStatements = append(idStatements, codegen.AssignStatement{
+			Vars:   []string{fmt.Sprintf("%s[i].%s", g.param(1), g.idField.Var.Name())},
+			Values: codegen.StatementList{codegen.Identifier("insertedID")},
+		})
+		return append(body,
+			codegen.RawBlock{
+				Header:     []string{"for i, id := range result.InsertedIDs"},
+				Statements: idStatements,
+			},
+			codegen.ReturnStatement{
+				codegen.Identifier(g.param(1)),
+				codegen.Identifier("nil"),
+			},
+		)
+
+	case g.operation.IDType != nil:
+		idStatements := g.assertInsertedID("id", g.operation.IDType, codegen.Identifier("nil"))
+		idStatements = append(idStatements, codegen.AssignStatement{
+			Vars: []string{"insertedIDs"},
+			Values: codegen.StatementList{
+				codegen.CallStatement{
+					FuncName: "append",
+					Params: codegen.StatementList{
+						codegen.Identifier("insertedIDs"),
+						codegen.Identifier("insertedID"),
+					},
+				},
+			},
+		})
+		return append(body,
+			codegen.NewDeclStatement(g.targetPkg, "insertedIDs", types.NewSlice(g.operation.IDType)),
+			codegen.RawBlock{
+				Header:     []string{"for _, id := range result.InsertedIDs"},
+				Statements: idStatements,
+			},
+			codegen.ReturnStatement{
+				codegen.Identifier("insertedIDs"),
+				codegen.Identifier("nil"),
+			},
+		)
+
+	default:
+		return append(body, codegen.ReturnStatement{
 			codegen.NewChainBuilder("result").Chain("InsertedIDs").Build(),
 			codegen.Identifier("nil"),
+		})
+	}
+}
+
+// assertInsertedID asserts the inserted ID to the type of the ID field into
+// insertedID variable. The generated code returns the ID type mismatch error
+// if the ID generated by the database is of another type.
+func (g insertBodyGenerator) assertInsertedID(insertedID string, idType types.Type,
+	zeroValue codegen.Statement) []codegen.Statement {
+
+	return []codegen.Statement{
+		codegen.DeclAssignStatement{
+			Vars: []string{"insertedID", "ok"},
+			Values: codegen.StatementList{
+				codegen.RawStatement(fmt.Sprintf("%s.(%s)", insertedID,
+					codegen.TypeToString(g.targetPkg, idType))),
+			},
+		},
+		codegen.IfBlock{
+			Condition: []codegen.Statement{
+				codegen.RawStatement("!ok"),
+			},
+			Statements: []codegen.Statement{
+				codegen.ReturnStatement{
+					zeroValue,
+					idTypeMismatchError{
+						Sentinel: g.idTypeMismatchErr,
+						ID:       insertedID,
+					},
+				},
+			},
 		},
 	}
 }
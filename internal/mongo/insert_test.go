@@ -76,6 +76,139 @@ func TestGenerateMethod_Insert(t *testing.T) {
 	}
 	return result.InsertedIDs, nil`,
 		},
+		{
+			Name: "insert one method with typed inserted ID",
+			MethodSpec: spec.MethodSpec{
+				Name: "InsertOneID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+					},
+					[]*types.Var{
+						createTypeVar(testutils.TypeObjectIDNamed),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode:   spec.QueryModeOne,
+					IDType: testutils.TypeObjectIDNamed,
+				},
+			},
+			ExpectedBody: `	var insertedID primitive.ObjectID
+	result, err := r.collection.InsertOne(arg0, arg1)
+	if err != nil {
+		return insertedID, err
+	}
+	insertedID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return insertedID, fmt.Errorf("%w: %T", ErrUserRepositoryInsertedIDTypeMismatch, result.InsertedID)
+	}
+	return insertedID, nil`,
+		},
+		{
+			Name: "insert many method with typed inserted IDs",
+			MethodSpec: spec.MethodSpec{
+				Name: "InsertManyIDs",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserNamed))),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(testutils.TypeObjectIDNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode:   spec.QueryModeMany,
+					IDType: testutils.TypeObjectIDNamed,
+				},
+			},
+			ExpectedBody: `	var entities []interface{}
+	for _, model := range arg1 {
+		entities = append(entities, model)
+	}
+	result, err := r.collection.InsertMany(arg0, entities)
+	if err != nil {
+		return nil, err
+	}
+	var insertedIDs []primitive.ObjectID
+	for _, id := range result.InsertedIDs {
+		insertedID, ok := id.(primitive.ObjectID)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUserRepositoryInsertedIDTypeMismatch, id)
+		}
+		insertedIDs = append(insertedIDs, insertedID)
+	}
+	return insertedIDs, nil`,
+		},
+		{
+			Name: "insert one method returning inserted model",
+			MethodSpec: spec.MethodSpec{
+				Name: "InsertOneModel",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode:        spec.QueryModeOne,
+					ReturnModel: true,
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.InsertOne(arg0, arg1)
+	if err != nil {
+		return nil, err
+	}
+	insertedID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUserRepositoryInsertedIDTypeMismatch, result.InsertedID)
+	}
+	arg1.ID = insertedID
+	return arg1, nil`,
+		},
+		{
+			Name: "insert many method returning inserted models",
+			MethodSpec: spec.MethodSpec{
+				Name: "InsertManyModels",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserNamed))),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserNamed))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode:        spec.QueryModeMany,
+					ReturnModel: true,
+				},
+			},
+			ExpectedBody: `	var entities []interface{}
+	for _, model := range arg1 {
+		entities = append(entities, model)
+	}
+	result, err := r.collection.InsertMany(arg0, entities)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range result.InsertedIDs {
+		insertedID, ok := id.(primitive.ObjectID)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUserRepositoryInsertedIDTypeMismatch, id)
+		}
+		arg1[i].ID = insertedID
+	}
+	return arg1, nil`,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -139,3 +272,114 @@ func TestGenerateMethod_Insert(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateMethod_InsertWithTimestamps(t *testing.T) {
+	testTable := []GenerateMethodTestCase{
+		{
+			Name: "insert one method",
+			MethodSpec: spec.MethodSpec{
+				Name: "InsertOne",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewPointer(testutils.TypeArticleNamed)),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewInterfaceType(nil, nil)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode: spec.QueryModeOne,
+				},
+			},
+			ExpectedBody: `	now := time.Now()
+	arg1.CreatedAt = now
+	arg1.UpdatedAt = primitive.NewDateTimeFromTime(now)
+	result, err := r.collection.InsertOne(arg0, arg1)
+	if err != nil {
+		return nil, err
+	}
+	return result.InsertedID, nil`,
+		},
+		{
+			Name: "insert many method",
+			MethodSpec: spec.MethodSpec{
+				Name: "Insert",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeArticleNamed))),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewInterfaceType(nil, nil))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode: spec.QueryModeMany,
+				},
+			},
+			ExpectedBody: `	now := time.Now()
+	var entities []interface{}
+	for _, model := range arg1 {
+		model.CreatedAt = now
+		model.UpdatedAt = primitive.NewDateTimeFromTime(now)
+		entities = append(entities, model)
+	}
+	result, err := r.collection.InsertMany(arg0, entities)
+	if err != nil {
+		return nil, err
+	}
+	return result.InsertedIDs, nil`,
+		},
+		{
+			Name: "insert many method with models by value",
+			MethodSpec: spec.MethodSpec{
+				Name: "Insert",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(testutils.TypeArticleNamed)),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(types.NewInterfaceType(nil, nil))),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode:       spec.QueryModeMany,
+					ValueModel: true,
+				},
+			},
+			ExpectedBody: `	now := time.Now()
+	var entities []interface{}
+	for i := range arg1 {
+		model := &arg1[i]
+		model.CreatedAt = now
+		model.UpdatedAt = primitive.NewDateTimeFromTime(now)
+		entities = append(entities, model)
+	}
+	result, err := r.collection.InsertMany(arg0, entities)
+	if err != nil {
+		return nil, err
+	}
+	return result.InsertedIDs, nil`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeArticleNamed, "ArticleRepository")
+
+			actual, err := generator.GenerateMethod(testCase.MethodSpec)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutils.ExpectMultiLineString(testCase.ExpectedBody, actual.Body.Code()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
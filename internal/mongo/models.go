@@ -4,14 +4,16 @@ import (
 	"fmt"
 	"go/types"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/sunboyy/repogen/internal/codegen"
 	"github.com/sunboyy/repogen/internal/spec"
 )
 
 type updateField struct {
-	BsonTag    string
-	ParamIndex int
+	BsonTag string
+	Value   codegen.Statement
 }
 
 type update interface {
@@ -19,6 +21,8 @@ type update interface {
 }
 
 type updateModel struct {
+	// Model is the name of the parameter holding the model.
+	Model string
 }
 
 func (u updateModel) Code() codegen.Statement {
@@ -27,7 +31,64 @@ func (u updateModel) Code() codegen.Statement {
 		Pairs: []codegen.MapPair{
 			{
 				Key:   "$set",
-				Value: codegen.Identifier("arg1"),
+				Value: codegen.Identifier(u.Model),
+			},
+		},
+	}
+}
+
+// updatePatch sets the fields collected in the patch fields variable at
+// runtime. The timestamp fields, if any, are set by the other operators such
+// as $currentDate.
+type updatePatch struct {
+	Timestamps updateFields
+}
+
+// updateIdentifier is the variable that holds the update document of the
+// patch-type update when the timestamp fields are set.
+const updateIdentifier = "update"
+
+func (u updatePatch) Code() codegen.Statement {
+	if len(u.Timestamps) > 0 {
+		return codegen.Identifier(updateIdentifier)
+	}
+
+	return codegen.MapStatement{
+		Type: "bson.M",
+		Pairs: []codegen.MapPair{
+			{
+				Key:   "$set",
+				Value: codegen.Identifier(patchIdentifier),
+			},
+		},
+	}
+}
+
+// Statements declares the update document when the timestamp fields are set
+// so that $set operator is left out if every field of the patch is nil.
+func (u updatePatch) Statements() []codegen.Statement {
+	if len(u.Timestamps) == 0 {
+		return nil
+	}
+
+	return []codegen.Statement{
+		codegen.DeclAssignStatement{
+			Vars: []string{updateIdentifier},
+			Values: codegen.StatementList{
+				u.Timestamps.Code(),
+			},
+		},
+		codegen.IfBlock{
+			Condition: []codegen.Statement{
+				codegen.RawStatement(fmt.Sprintf("len(%s) > 0", patchIdentifier)),
+			},
+			Statements: []codegen.Statement{
+				codegen.AssignStatement{
+					Vars: []string{fmt.Sprintf(`%s["$set"]`, updateIdentifier)},
+					Values: codegen.StatementList{
+						codegen.Identifier(patchIdentifier),
+					},
+				},
 			},
 		},
 	}
@@ -35,6 +96,18 @@ func (u updateModel) Code() codegen.Statement {
 
 type updateFields map[string][]updateField
 
+// hasField returns true if any operator of the update applies to the field.
+func (u updateFields) hasField(bsonTag string) bool {
+	for _, fields := range u {
+		for _, field := range fields {
+			if field.BsonTag == bsonTag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (u updateFields) Code() codegen.Statement {
 	var keys []string
 	for k := range u {
@@ -53,7 +126,7 @@ func (u updateFields) Code() codegen.Statement {
 		for _, field := range u[key] {
 			applicationMap.Pairs = append(applicationMap.Pairs, codegen.MapPair{
 				Key:   field.BsonTag,
-				Value: codegen.Identifier(fmt.Sprintf("arg%d", field.ParamIndex)),
+				Value: field.Value,
 			})
 		}
 
@@ -71,7 +144,121 @@ type querySpec struct {
 	Predicates []predicate
 }
 
+// filterIdentifier is the variable holding the query filter that is built at
+// runtime.
+const filterIdentifier = "filter"
+
+// isDynamic returns true if the query has any optional predicate, i.e. the
+// filter has to be built at runtime.
+func (q querySpec) isDynamic() bool {
+	for _, predicate := range q.Predicates {
+		if predicate.Optional {
+			return true
+		}
+	}
+	return false
+}
+
+// Statements generates the statements that build the filter at runtime. The
+// optional predicates are added to the filter only if their arguments are not
+// nil. It returns nil if the query has no optional predicate.
+func (q querySpec) Statements() []codegen.Statement {
+	if !q.isDynamic() {
+		return nil
+	}
+
+	if q.Operator == spec.OperatorAnd || q.Operator == spec.OperatorOr {
+		return q.conditionsStatements()
+	}
+
+	stmts := []codegen.Statement{
+		codegen.DeclAssignStatement{
+			Vars:   []string{filterIdentifier},
+			Values: codegen.StatementList{codegen.RawStatement("bson.M{}")},
+		},
+	}
+	for _, predicate := range q.Predicates {
+		pair := predicate.Code()
+		stmts = append(stmts, predicate.ifPresent(codegen.AssignStatement{
+			Vars:   []string{fmt.Sprintf("%s[%s]", filterIdentifier, strconv.Quote(pair.Key))},
+			Values: codegen.StatementList{pair.Value},
+		}))
+	}
+	return stmts
+}
+
+// conditionsStatements builds the conditions of the And and Or operators in a
+// slice. The operator is applied only if any condition is present.
+func (q querySpec) conditionsStatements() []codegen.Statement {
+	var requiredMaps []codegen.Statement
+	var optionalStmts []codegen.Statement
+	for _, predicate := range q.Predicates {
+		predicateMap := codegen.MapStatement{
+			Pairs: []codegen.MapPair{predicate.Code()},
+		}
+		if !predicate.Optional {
+			requiredMaps = append(requiredMaps, predicateMap)
+			continue
+		}
+
+		predicateMap.Type = "bson.M"
+		optionalStmts = append(optionalStmts, predicate.ifPresent(codegen.AssignStatement{
+			Vars: []string{"conditions"},
+			Values: codegen.StatementList{
+				codegen.CallStatement{
+					FuncName: "append",
+					Params: codegen.StatementList{
+						codegen.Identifier("conditions"),
+						predicateMap,
+					},
+				},
+			},
+		}))
+	}
+
+	var stmts []codegen.Statement
+	if len(requiredMaps) == 0 {
+		stmts = append(stmts, codegen.NewDeclStatement(q.TargetPkg, "conditions", types.NewSlice(bsonMType)))
+	} else {
+		stmts = append(stmts, codegen.DeclAssignStatement{
+			Vars: []string{"conditions"},
+			Values: codegen.StatementList{
+				codegen.NewSliceStatement(q.TargetPkg, types.NewSlice(bsonMType), requiredMaps),
+			},
+		})
+	}
+	stmts = append(stmts, optionalStmts...)
+
+	operatorKey := "$and"
+	if q.Operator == spec.OperatorOr {
+		operatorKey = "$or"
+	}
+	return append(stmts,
+		codegen.DeclAssignStatement{
+			Vars:   []string{filterIdentifier},
+			Values: codegen.StatementList{codegen.RawStatement("bson.M{}")},
+		},
+		codegen.IfBlock{
+			Condition: []codegen.Statement{
+				codegen.RawStatement("len(conditions) > 0"),
+			},
+			Statements: []codegen.Statement{
+				codegen.AssignStatement{
+					Vars:   []string{fmt.Sprintf("%s[%s]", filterIdentifier, strconv.Quote(operatorKey))},
+					Values: codegen.StatementList{codegen.Identifier("conditions")},
+				},
+			},
+		},
+	)
+}
+
+// Code generates the query filter. It refers to the filter built by
+// Statements if the query has any optional predicate.
 func (q querySpec) Code() codegen.Statement {
+	if q.isDynamic() {
+		return codegen.Identifier(filterIdentifier)
+	}
+
 	var predicatePairs []codegen.MapPair
 	for _, predicate := range q.Predicates {
 		predicatePairs = append(predicatePairs, predicate.Code())
@@ -113,12 +300,89 @@ func (q querySpec) Code() codegen.Statement {
 
 type predicate struct {
 	Field      string
+	FieldType  types.Type
 	Comparator spec.Comparator
-	ParamIndex int
+	// Params is the names of the parameters that hold the arguments. It
+	// contains only the query struct parameter if ParamFields is given.
+	Params  []string
+	Negated bool
+	// ComparedField is the bson field reference of the other field to compare
+	// with. It is empty when the predicate compares with an argument.
+	ComparedField string
+	// ParamFields is the names of the fields of the query struct parameter
+	// that hold the arguments. It is empty for the positional parameters.
+	ParamFields []string
+	// Optional indicates that the arguments are pointers and the predicate is
+	// omitted when any of them is nil.
+	Optional bool
+}
+
+// argument returns the expression of the i-th argument of the predicate.
+func (p predicate) argument(i int) string {
+	if len(p.ParamFields) > 0 {
+		return fmt.Sprintf("%s.%s", p.Params[0], p.ParamFields[i])
+	}
+	return p.Params[i]
+}
+
+// argumentValue returns the value of the i-th argument of the predicate,
+// dereferencing the argument if the predicate is optional.
+func (p predicate) argumentValue(i int) string {
+	if p.Optional {
+		return "*" + p.argument(i)
+	}
+	return p.argument(i)
+}
+
+// ifPresent wraps the statement to be executed only if all arguments of the
+// optional predicate are not nil.
+func (p predicate) ifPresent(stmt codegen.Statement) codegen.Statement {
+	if !p.Optional {
+		return stmt
+	}
+
+	var conditions []string
+	for i := 0; i < p.Comparator.NumberOfArguments(); i++ {
+		conditions = append(conditions, p.argument(i)+" != nil")
+	}
+	return codegen.IfBlock{
+		Condition: []codegen.Statement{
+			codegen.RawStatement(strings.Join(conditions, " && ")),
+		},
+		Statements: []codegen.Statement{stmt},
+	}
 }
 
 func (p predicate) Code() codegen.MapPair {
-	argStmt := codegen.Identifier(fmt.Sprintf("arg%d", p.ParamIndex))
+	pair := p.comparisonCode()
+	if !p.Negated {
+		return pair
+	}
+
+	// $not only accepts operator expressions. Comparisons by value are negated
+	// with $ne instead.
+	negationKey := "$ne"
+	if _, ok := pair.Value.(codegen.MapStatement); ok {
+		negationKey = "$not"
+	}
+	return codegen.MapPair{
+		Key: pair.Key,
+		Value: codegen.MapStatement{
+			Type:  "bson.M",
+			Pairs: []codegen.MapPair{{Key: negationKey, Value: pair.Value}},
+		},
+	}
+}
+
+func (p predicate) comparisonCode() codegen.MapPair {
+	if p.ComparedField != "" {
+		return p.createFieldComparisonMapPair()
+	}
+
+	var argStmt codegen.Identifier
+	if p.Comparator.NumberOfArguments() > 0 {
+		argStmt = codegen.Identifier(p.argumentValue(0))
+	}
 
 	switch p.Comparator {
 	case spec.ComparatorEqual:
@@ -134,7 +398,7 @@ func (p predicate) Code() codegen.MapPair {
 	case spec.ComparatorGreaterThanEqual:
 		return p.createSingleComparisonMapPair("$gte", argStmt)
 	case spec.ComparatorBetween:
-		argStmt2 := codegen.Identifier(fmt.Sprintf("arg%d", p.ParamIndex+1))
+		argStmt2 := codegen.Identifier(p.argumentValue(1))
 		return p.createBetweenMapPair(argStmt, argStmt2)
 	case spec.ComparatorIn:
 		return p.createSingleComparisonMapPair("$in", argStmt)
@@ -148,10 +412,56 @@ func (p predicate) Code() codegen.MapPair {
 		return p.createExistsMapPair("1")
 	case spec.ComparatorNotExists:
 		return p.createExistsMapPair("0")
+	case spec.ComparatorTextSearch:
+		return p.createTextSearchMapPair(argStmt)
+	case spec.ComparatorIsNull:
+		return p.createValueMapPair(codegen.Identifier("nil"))
+	case spec.ComparatorIsNotNull:
+		return p.createSingleComparisonMapPair("$ne", codegen.Identifier("nil"))
+	case spec.ComparatorBefore:
+		return p.createSingleComparisonMapPair("$lt", argStmt)
+	case spec.ComparatorAfter:
+		return p.createSingleComparisonMapPair("$gt", argStmt)
+	case spec.ComparatorWithinLast:
+		return p.createSingleComparisonMapPair("$gte", timeValue(p.FieldType, codegen.NewChainBuilder("time").
+			Call("Now").
+			Call("Add", codegen.RawStatement("-"+p.argumentValue(0))).
+			Build()))
 	}
 	return codegen.MapPair{}
 }
 
+var fieldComparisonOperators = map[spec.Comparator]string{
+	spec.ComparatorLessThan:         "$lt",
+	spec.ComparatorLessThanEqual:    "$lte",
+	spec.ComparatorGreaterThan:      "$gt",
+	spec.ComparatorGreaterThanEqual: "$gte",
+}
+
+// createFieldComparisonMapPair compares two fields of the same document with
+// an aggregation expression.
+func (p predicate) createFieldComparisonMapPair() codegen.MapPair {
+	return codegen.MapPair{
+		Key: "$expr",
+		Value: codegen.MapStatement{
+			Type: "bson.M",
+			Pairs: []codegen.MapPair{
+				{
+					Key: fieldComparisonOperators[p.Comparator],
+					Value: codegen.NewSliceStatement(
+						nil,
+						types.NewSlice(types.NewInterfaceType(nil, nil)),
+						[]codegen.Statement{
+							codegen.Identifier(fmt.Sprintf(`"$%s"`, p.Field)),
+							codegen.Identifier(fmt.Sprintf(`"$%s"`, p.ComparedField)),
+						},
+					),
+				},
+			},
+		},
+	}
+}
+
 func (p predicate) createValueMapPair(
 	argStmt codegen.Statement) codegen.MapPair {
 
@@ -200,3 +510,13 @@ func (p predicate) createExistsMapPair(existsValue string) codegen.MapPair {
 		},
 	}
 }
+
+func (p predicate) createTextSearchMapPair(argStmt codegen.Statement) codegen.MapPair {
+	return codegen.MapPair{
+		Key: "$text",
+		Value: codegen.MapStatement{
+			Type:  "bson.M",
+			Pairs: []codegen.MapPair{{Key: "$search", Value: argStmt}},
+		},
+	}
+}
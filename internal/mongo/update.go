@@ -1,6 +1,10 @@
 package mongo
 
 import (
+	"fmt"
+	"go/types"
+	"strconv"
+
 	"github.com/sunboyy/repogen/internal/codegen"
 	"github.com/sunboyy/repogen/internal/spec"
 )
@@ -11,12 +15,16 @@ func (g RepositoryGenerator) generateUpdateBody(
 	return updateBodyGenerator{
 		baseMethodGenerator: g.baseMethodGenerator,
 		operation:           operation,
+		idTypeMismatchErr:   g.errInsertedIDTypeMismatch().Name,
 	}.generate()
 }
 
 type updateBodyGenerator struct {
 	baseMethodGenerator
 	operation spec.UpdateOperation
+	// idTypeMismatchErr is the sentinel error returned when the upserted ID
+	// is not of the expected type.
+	idTypeMismatchErr string
 }
 
 func (g updateBodyGenerator) generate() (codegen.FunctionBody, error) {
@@ -30,75 +38,299 @@ func (g updateBodyGenerator) generate() (codegen.FunctionBody, error) {
 		return nil, err
 	}
 
+	updateArgs := []codegen.Statement{
+		codegen.Identifier(g.param(0)),
+		querySpec.Code(),
+		update.Code(),
+	}
+
+	updateOptions, err := g.generateUpdateOptions()
+	if err != nil {
+		return nil, err
+	}
+	if updateOptions != nil {
+		updateArgs = append(updateArgs, updateOptions)
+	}
+
+	body, err := g.generateTimestampStatements()
+	if err != nil {
+		return nil, err
+	}
+
+	patchStatements, err := g.generatePatchStatements()
+	if err != nil {
+		return nil, err
+	}
+	body = append(body, patchStatements...)
+	body = append(body, querySpec.Statements()...)
+
+	if g.operation.UpsertedIDType != nil {
+		body = append(body, codegen.NewDeclStatement(g.targetPkg, upsertedIDIdentifier,
+			g.operation.UpsertedIDType))
+	}
+
+	if updatePatch, ok := update.(updatePatch); ok {
+		if len(updatePatch.Timestamps) == 0 {
+			body = append(body, g.generateEmptyPatchStatements(querySpec))
+		}
+		body = append(body, updatePatch.Statements()...)
+	}
+
 	if g.operation.Mode == spec.QueryModeOne {
-		return g.generateUpdateOneBody(update, querySpec), nil
+		return append(body, g.generateUpdateOneBody(updateArgs)...), nil
+	}
+
+	return append(body, g.generateUpdateManyBody(updateArgs)...), nil
+}
+
+// generateTimestampStatements sets the updated timestamp field of the model
+// before updating the whole model. The timestamp of the fields-type update is
+// set by $currentDate operator instead.
+func (g updateBodyGenerator) generateTimestampStatements() (codegen.FunctionBody, error) {
+	if _, ok := g.operation.Update.(spec.UpdateModel); !ok {
+		return nil, nil
+	}
+
+	timestampFields, err := g.timestampFields(timestampUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(timestampFields) == 0 {
+		return nil, nil
+	}
+
+	body := codegen.FunctionBody{declareNow}
+	return append(body, timestampAssignStatements(g.param(1), timestampFields)...), nil
+}
+
+// patchIdentifier is the variable that collects the non-nil fields of the
+// patch struct.
+const patchIdentifier = "patchFields"
+
+// generatePatchStatements builds the $set document from the patch struct,
+// skipping the fields that are nil.
+func (g updateBodyGenerator) generatePatchStatements() (codegen.FunctionBody, error) {
+	updatePatch, ok := g.operation.Update.(spec.UpdatePatch)
+	if !ok {
+		return nil, nil
+	}
+
+	body := codegen.FunctionBody{
+		codegen.DeclAssignStatement{
+			Vars: []string{patchIdentifier},
+			Values: codegen.StatementList{
+				codegen.RawStatement("bson.M{}"),
+			},
+		},
+	}
+
+	for _, field := range updatePatch {
+		bsonFieldReference, err := g.bsonFieldReference(field.FieldReference)
+		if err != nil {
+			return nil, err
+		}
+
+		body = append(body, codegen.IfBlock{
+			Condition: []codegen.Statement{
+				codegen.RawStatement(fmt.Sprintf("%s.%s != nil", g.param(1), field.Name)),
+			},
+			Statements: []codegen.Statement{
+				codegen.AssignStatement{
+					Vars: []string{fmt.Sprintf(`%s["%s"]`, patchIdentifier, bsonFieldReference)},
+					Values: codegen.StatementList{
+						codegen.RawStatement(fmt.Sprintf("*%s.%s", g.param(1), field.Name)),
+					},
+				},
+			},
+		})
 	}
 
-	return g.generateUpdateManyBody(update, querySpec), nil
+	return body, nil
 }
 
-func (g updateBodyGenerator) generateUpdateOneBody(update update,
-	querySpec querySpec) codegen.FunctionBody {
+// generateEmptyPatchStatements counts the matching documents instead of
+// updating them when every field of the patch struct is nil since the update
+// document would otherwise be empty.
+func (g updateBodyGenerator) generateEmptyPatchStatements(querySpec querySpec) codegen.IfBlock {
+	var matched codegen.Statement = codegen.RawStatement("count > 0")
+	zeroValue := codegen.Identifier("false")
+	if g.operation.Mode == spec.QueryModeMany {
+		matched = codegen.CallStatement{
+			FuncName: "int",
+			Params: codegen.StatementList{
+				codegen.Identifier("count"),
+			},
+		}
+		zeroValue = codegen.Identifier("0")
+	}
+
+	return codegen.IfBlock{
+		Condition: []codegen.Statement{
+			codegen.RawStatement(fmt.Sprintf("len(%s) == 0", patchIdentifier)),
+		},
+		Statements: []codegen.Statement{
+			codegen.DeclAssignStatement{
+				Vars: []string{"count", "err"},
+				Values: codegen.StatementList{
+					codegen.NewChainBuilder("r").
+						Chain("collection").
+						Call("CountDocuments",
+							codegen.Identifier(g.param(0)),
+							querySpec.Code(),
+						).Build(),
+				},
+			},
+			g.ifErrReturn(zeroValue),
+			g.returnResults(matched, zeroValue, codegen.Identifier("nil")),
+		},
+	}
+}
 
-	return codegen.FunctionBody{
+func (g updateBodyGenerator) generateUpdateOneBody(updateArgs []codegen.Statement) codegen.FunctionBody {
+	matchedCondition := "result.MatchedCount > 0"
+	if g.operation.Upsert {
+		matchedCondition += " || result.UpsertedCount > 0"
+	}
+
+	body := codegen.FunctionBody{
 		codegen.DeclAssignStatement{
 			Vars: []string{"result", "err"},
 			Values: codegen.StatementList{
 				codegen.NewChainBuilder("r").
 					Chain("collection").
-					Call("UpdateOne",
-						codegen.Identifier("arg0"),
-						querySpec.Code(),
-						update.Code(),
-					).Build(),
+					Call("UpdateOne", updateArgs...).Build(),
 			},
 		},
-		ifErrReturnFalseErr,
-		codegen.ReturnStatement{
-			codegen.RawStatement("result.MatchedCount > 0"),
-			codegen.Identifier("nil"),
-		},
+		g.ifErrReturn(codegen.Identifier("false")),
 	}
+	body = append(body, g.assignUpsertedID(codegen.Identifier("false"))...)
+
+	return append(body, g.returnResults(
+		codegen.RawStatement(matchedCondition),
+		codegen.RawStatement("result.ModifiedCount > 0"),
+		codegen.Identifier("nil"),
+	))
 }
 
-func (g updateBodyGenerator) generateUpdateManyBody(update update,
-	querySpec querySpec) codegen.FunctionBody {
+func (g updateBodyGenerator) generateUpdateManyBody(updateArgs []codegen.Statement) codegen.FunctionBody {
+	var matchedCount codegen.Statement = codegen.NewChainBuilder("result").Chain("MatchedCount").Build()
+	if g.operation.Upsert {
+		matchedCount = codegen.RawStatement("result.MatchedCount + result.UpsertedCount")
+	}
 
-	return codegen.FunctionBody{
+	body := codegen.FunctionBody{
 		codegen.DeclAssignStatement{
 			Vars: []string{"result", "err"},
 			Values: codegen.StatementList{
 				codegen.NewChainBuilder("r").
 					Chain("collection").
-					Call("UpdateMany",
-						codegen.Identifier("arg0"),
-						querySpec.Code(),
-						update.Code(),
-					).Build(),
+					Call("UpdateMany", updateArgs...).Build(),
+			},
+		},
+		g.ifErrReturn(codegen.Identifier("0")),
+	}
+	body = append(body, g.assignUpsertedID(codegen.Identifier("0"))...)
+
+	return append(body, g.returnResults(
+		codegen.CallStatement{
+			FuncName: "int",
+			Params: codegen.StatementList{
+				matchedCount,
+			},
+		},
+		codegen.CallStatement{
+			FuncName: "int",
+			Params: codegen.StatementList{
+				codegen.NewChainBuilder("result").Chain("ModifiedCount").Build(),
 			},
 		},
-		ifErrReturn0Err,
-		codegen.ReturnStatement{
-			codegen.CallStatement{
-				FuncName: "int",
-				Params: codegen.StatementList{
-					codegen.NewChainBuilder("result").
-						Chain("MatchedCount").Build(),
+		codegen.Identifier("nil"),
+	))
+}
+
+// upsertedIDIdentifier is the variable that holds the ID of the upserted
+// document.
+const upsertedIDIdentifier = "upsertedID"
+
+// assignUpsertedID asserts the upserted ID to the type of the ID field and
+// assigns it to the upserted ID variable if a document is upserted.
+func (g updateBodyGenerator) assignUpsertedID(zeroValue codegen.Statement) []codegen.Statement {
+	if g.operation.UpsertedIDType == nil {
+		return nil
+	}
+
+	return []codegen.Statement{
+		codegen.IfBlock{
+			Condition: []codegen.Statement{
+				codegen.RawStatement("result.UpsertedID != nil"),
+			},
+			Statements: []codegen.Statement{
+				codegen.DeclAssignStatement{
+					Vars: []string{"upserted", "ok"},
+					Values: codegen.StatementList{
+						codegen.RawStatement(fmt.Sprintf("result.UpsertedID.(%s)",
+							codegen.TypeToString(g.targetPkg, g.operation.UpsertedIDType))),
+					},
+				},
+				codegen.IfBlock{
+					Condition: []codegen.Statement{
+						codegen.RawStatement("!ok"),
+					},
+					Statements: []codegen.Statement{
+						g.returnResults(zeroValue, zeroValue, idTypeMismatchError{
+							Sentinel: g.idTypeMismatchErr,
+							ID:       "result.UpsertedID",
+						}),
+					},
+				},
+				codegen.AssignStatement{
+					Vars: []string{upsertedIDIdentifier},
+					Values: codegen.StatementList{
+						codegen.Identifier("upserted"),
+					},
 				},
 			},
-			codegen.Identifier("nil"),
 		},
 	}
 }
 
+// ifErrReturn returns the zero value of each result along with the error. The
+// zero value is repeated for the modified result if it is returned.
+func (g updateBodyGenerator) ifErrReturn(zeroValue codegen.Statement) codegen.IfBlock {
+	return codegen.IfBlock{
+		Condition: []codegen.Statement{
+			errOccurred,
+		},
+		Statements: []codegen.Statement{
+			g.returnResults(zeroValue, zeroValue, codegen.Identifier("err")),
+		},
+	}
+}
+
+// returnResults returns the matched result, followed by the modified result
+// and the upserted ID if they are required by the method, followed by the
+// error.
+func (g updateBodyGenerator) returnResults(matched codegen.Statement, modified codegen.Statement,
+	err codegen.Statement) codegen.ReturnStatement {
+
+	stmt := codegen.ReturnStatement{matched}
+	if g.operation.ReturnModified {
+		stmt = append(stmt, modified)
+	}
+	if g.operation.UpsertedIDType != nil {
+		stmt = append(stmt, codegen.Identifier(upsertedIDIdentifier))
+	}
+	return append(stmt, err)
+}
+
 func (g updateBodyGenerator) convertUpdate(updateSpec spec.Update) (update, error) {
 	switch updateSpec := updateSpec.(type) {
 	case spec.UpdateModel:
-		return updateModel{}, nil
+		return updateModel{Model: g.param(1)}, nil
 	case spec.UpdateFields:
 		update := make(updateFields)
 		for _, field := range updateSpec {
-			bsonFieldReference, err := g.bsonFieldReference(field.FieldReference)
+			bsonFieldReference, err := g.updateFieldBsonReference(field)
 			if err != nil {
 				return nil, err
 			}
@@ -108,26 +340,309 @@ func (g updateBodyGenerator) convertUpdate(updateSpec spec.Update) (update, erro
 				return nil, NewUpdateOperatorNotSupportedError(field.Operator)
 			}
 			updateField := updateField{
-				BsonTag:    bsonFieldReference,
-				ParamIndex: field.ParamIndex,
+				BsonTag: bsonFieldReference,
+				Value:   getUpdateValue(field, g.param(field.ParamIndex)),
 			}
 			update[updateKey] = append(update[updateKey], updateField)
 		}
+
+		if err := g.applyTimestamps(update); err != nil {
+			return nil, err
+		}
+		return update, nil
+	case spec.UpdatePatch:
+		update := make(updateFields)
+		for _, field := range updateSpec {
+			bsonFieldReference, err := g.bsonFieldReference(field.FieldReference)
+			if err != nil {
+				return nil, err
+			}
+			update["$set"] = append(update["$set"], updateField{BsonTag: bsonFieldReference})
+		}
+
+		if err := g.applyTimestamps(update); err != nil {
+			return nil, err
+		}
+		// the patch fields are set at runtime instead
+		delete(update, "$set")
+		return updatePatch{Timestamps: update}, nil
+	case spec.UpdateRename:
+		fromBsonFieldReference, err := g.bsonFieldReference(updateSpec.From)
+		if err != nil {
+			return nil, err
+		}
+		toBsonFieldReference, err := g.bsonFieldReference(updateSpec.To)
+		if err != nil {
+			return nil, err
+		}
+
+		update := updateFields{
+			"$rename": []updateField{
+				{
+					BsonTag: fromBsonFieldReference,
+					Value:   codegen.Identifier(strconv.Quote(toBsonFieldReference)),
+				},
+			},
+		}
+		if err := g.applyTimestamps(update); err != nil {
+			return nil, err
+		}
 		return update, nil
 	default:
 		return nil, NewUpdateTypeNotSupportedError(updateSpec)
 	}
 }
 
+// arrayFilterIdentifier is the identifier of the array elements matched by
+// the filtered positional operator.
+const arrayFilterIdentifier = "elem"
+
+var positionalOperators = map[spec.Positional]string{
+	spec.PositionalFirst:    "$",
+	spec.PositionalFiltered: "$[" + arrayFilterIdentifier + "]",
+}
+
+func (g updateBodyGenerator) updateFieldBsonReference(field spec.UpdateField) (string, error) {
+	if field.Positional == "" {
+		return g.bsonFieldReference(field.FieldReference)
+	}
+
+	arrayBsonReference, err := g.bsonFieldReference(field.FieldReference[:field.PositionalDepth])
+	if err != nil {
+		return "", err
+	}
+	bsonReference := arrayBsonReference + "." + positionalOperators[field.Positional]
+
+	if field.PositionalDepth < len(field.FieldReference) {
+		elemBsonReference, err := g.bsonFieldReference(field.FieldReference[field.PositionalDepth:])
+		if err != nil {
+			return "", err
+		}
+		bsonReference += "." + elemBsonReference
+	}
+
+	return bsonReference, nil
+}
+
+// convertArrayFilters derives the conditions of the array elements updated by
+// the filtered positional operator from the query predicates on that array.
+func (g updateBodyGenerator) convertArrayFilters() (codegen.MapStatement, error) {
+	filter := codegen.MapStatement{
+		Type: "bson.M",
+	}
+
+	updateFields, ok := g.operation.Update.(spec.UpdateFields)
+	if !ok {
+		return filter, nil
+	}
+
+	for _, field := range updateFields {
+		if field.Positional != spec.PositionalFiltered {
+			continue
+		}
+
+		arrayFields := field.FieldReference[:field.PositionalDepth]
+		for _, predicateSpec := range g.operation.Query.Predicates {
+			if !predicateSpec.FieldReference.HasPrefix(arrayFields) {
+				continue
+			}
+
+			bsonReference := arrayFilterIdentifier
+			if len(predicateSpec.FieldReference) > len(arrayFields) {
+				elemBsonReference, err := g.bsonFieldReference(predicateSpec.FieldReference[len(arrayFields):])
+				if err != nil {
+					return codegen.MapStatement{}, err
+				}
+				bsonReference += "." + elemBsonReference
+			}
+
+			if predicateSpec.Optional {
+				return codegen.MapStatement{}, ErrOptionalArrayFilter
+			}
+
+			filter.Pairs = append(filter.Pairs, predicate{
+				Field:       bsonReference,
+				FieldType:   predicateSpec.FieldReference.ReferencedField().Var.Type(),
+				Comparator:  predicateSpec.Comparator,
+				Params:      g.predicateParams(predicateSpec),
+				Negated:     predicateSpec.Negated,
+				ParamFields: predicateSpec.ParamFields,
+			}.Code())
+		}
+
+		// all filtered positional operators share the same identifier
+		break
+	}
+
+	return filter, nil
+}
+
+// generateUpdateOptions generates the options of the update operation. It
+// returns nil if no option is required.
+func (g updateBodyGenerator) generateUpdateOptions() (codegen.Statement, error) {
+	arrayFilters, err := g.convertArrayFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.operation.Upsert && len(arrayFilters.Pairs) == 0 {
+		return nil, nil
+	}
+
+	builder := codegen.NewChainBuilder("options").Call("Update")
+	if g.operation.Upsert {
+		builder = builder.Call("SetUpsert", codegen.Identifier("true"))
+	}
+	if len(arrayFilters.Pairs) > 0 {
+		builder = builder.Call("SetArrayFilters", generateArrayFilters(arrayFilters))
+	}
+	return builder.Build(), nil
+}
+
+func generateArrayFilters(filter codegen.MapStatement) codegen.Statement {
+	return codegen.StructStatement{
+		Type: "options.ArrayFilters",
+		Pairs: []codegen.StructFieldPair{
+			{
+				Key: "Filters",
+				Value: codegen.NewSliceStatement(
+					nil,
+					types.NewSlice(types.NewInterfaceType(nil, nil)),
+					[]codegen.Statement{filter},
+				),
+			},
+		},
+	}
+}
+
+// applyTimestamps adds the operators that set the timestamp fields to the
+// fields-type update.
+func (g updateBodyGenerator) applyTimestamps(update updateFields) error {
+	if err := g.applyUpdatedTimestamp(update); err != nil {
+		return err
+	}
+	if g.operation.Upsert {
+		return g.applyCreatedTimestamp(update)
+	}
+	return nil
+}
+
+// applyCreatedTimestamp adds $setOnInsert operator to the created timestamp
+// field so that it is set only when the upsert inserts a new document, unless
+// the field is explicitly updated by the method.
+func (g updateBodyGenerator) applyCreatedTimestamp(update updateFields) error {
+	timestampField, ok, err := g.timestampField(timestampCreatedAt)
+	if err != nil || !ok || update.hasField(timestampField.BsonTag) {
+		return err
+	}
+
+	update["$setOnInsert"] = append(update["$setOnInsert"], updateField{
+		BsonTag: timestampField.BsonTag,
+		Value:   timeValue(timestampField.Type, codegen.NewChainBuilder("time").Call("Now").Build()),
+	})
+	return nil
+}
+
+// applyUpdatedTimestamp adds $currentDate operator to the updated timestamp
+// field unless the field is already updated by the method.
+func (g updateBodyGenerator) applyUpdatedTimestamp(update updateFields) error {
+	timestampField, ok, err := g.timestampField(timestampUpdatedAt)
+	if err != nil || !ok {
+		return err
+	}
+
+	if update.hasField(timestampField.BsonTag) {
+		return nil
+	}
+
+	update["$currentDate"] = append(update["$currentDate"], updateField{
+		BsonTag: timestampField.BsonTag,
+		Value:   codegen.Identifier("true"),
+	})
+	return nil
+}
+
 func getUpdateOperatorKey(operator spec.UpdateOperator) string {
 	switch operator {
 	case spec.UpdateOperatorSet:
 		return "$set"
-	case spec.UpdateOperatorPush:
+	case spec.UpdateOperatorPush, spec.UpdateOperatorPushEach:
 		return "$push"
 	case spec.UpdateOperatorInc:
 		return "$inc"
+	case spec.UpdateOperatorUnset:
+		return "$unset"
+	case spec.UpdateOperatorPull:
+		return "$pull"
+	case spec.UpdateOperatorPullAll:
+		return "$pullAll"
+	case spec.UpdateOperatorAddToSet:
+		return "$addToSet"
+	case spec.UpdateOperatorMin:
+		return "$min"
+	case spec.UpdateOperatorMax:
+		return "$max"
+	case spec.UpdateOperatorMul:
+		return "$mul"
+	case spec.UpdateOperatorCurrentDate:
+		return "$currentDate"
+	case spec.UpdateOperatorSetOnInsert:
+		return "$setOnInsert"
 	default:
 		return ""
 	}
 }
+
+func getUpdateValue(field spec.UpdateField, param string) codegen.Statement {
+	switch field.Operator {
+	case spec.UpdateOperatorUnset:
+		return codegen.Identifier("1")
+	case spec.UpdateOperatorCurrentDate:
+		return codegen.Identifier("true")
+	case spec.UpdateOperatorPushEach:
+		return getPushEachValue(field, param)
+	default:
+		return codegen.Identifier(param)
+	}
+}
+
+func getPushEachValue(field spec.UpdateField, param string) codegen.Statement {
+	stmt := codegen.MapStatement{
+		Type: "bson.M",
+		Pairs: []codegen.MapPair{
+			{
+				Key:   "$each",
+				Value: codegen.Identifier(param),
+			},
+		},
+	}
+
+	if field.PushEach.Position != nil {
+		stmt.Pairs = append(stmt.Pairs, codegen.MapPair{
+			Key:   "$position",
+			Value: codegen.Identifier(strconv.Itoa(*field.PushEach.Position)),
+		})
+	}
+
+	if field.PushEach.Slice > 0 {
+		stmt.Pairs = append(stmt.Pairs, codegen.MapPair{
+			Key:   "$slice",
+			Value: codegen.Identifier(fmt.Sprintf("-%d", field.PushEach.Slice)),
+		})
+	}
+
+	switch field.PushEach.Sort {
+	case spec.OrderingAscending:
+		stmt.Pairs = append(stmt.Pairs, codegen.MapPair{
+			Key:   "$sort",
+			Value: codegen.Identifier("1"),
+		})
+	case spec.OrderingDescending:
+		stmt.Pairs = append(stmt.Pairs, codegen.MapPair{
+			Key:   "$sort",
+			Value: codegen.Identifier("-1"),
+		})
+	}
+
+	return stmt
+}
@@ -15,6 +15,7 @@ import (
 )
 
 func TestGenerateMethod_Update(t *testing.T) {
+	position0 := 0
 	testTable := []GenerateMethodTestCase{
 		{
 			Name: "update model method",
@@ -260,38 +261,1333 @@ func TestGenerateMethod_Update(t *testing.T) {
 		return false, err
 	}
 	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update unset method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateReferrerUnsetAndCityByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorUnset,
+						},
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"city": arg1,
+		},
+		"$unset": bson.M{
+			"referrer": 1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "simple update pull all method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateTagsPullAllByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(code.TypeString)),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorPullAll,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$pullAll": bson.M{
+			"tags": arg1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "simple update pull and add to set method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateTagsPullAndConsentHistoryAddToSetByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeConsentHistoryNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorPull,
+						},
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+							},
+							ParamIndex: 2,
+							Operator:   spec.UpdateOperatorAddToSet,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 3,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg3,
+	}, bson.M{
+		"$addToSet": bson.M{
+			"consent_history": arg2,
+		},
+		"$pull": bson.M{
+			"tags": arg1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update push each method with modifiers",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateTagsPushEachSlice50SortDescByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(code.TypeString)),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorPushEach,
+							PushEach: spec.PushEachModifiers{
+								Slice: 50,
+								Sort:  spec.OrderingDescending,
+							},
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$push": bson.M{
+			"tags": bson.M{
+				"$each": arg1,
+				"$slice": -50,
+				"$sort": -1,
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update push each method with position modifier",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateTagsPushEachPosition0ByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(code.TypeString)),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorPushEach,
+							PushEach: spec.PushEachModifiers{
+								Position: &position0,
+							},
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$push": bson.M{
+			"tags": bson.M{
+				"$each": arg1,
+				"$position": 0,
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update min and mul method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateAgeMinAndAgeMulByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeInt),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorMin,
+						},
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+							},
+							ParamIndex: 2,
+							Operator:   spec.UpdateOperatorMul,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 3,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg3,
+	}, bson.M{
+		"$min": bson.M{
+			"age": arg1,
+		},
+		"$mul": bson.M{
+			"age": arg2,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update max method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateLastLoginMaxByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeDateTimeNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "LastLogin"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorMax,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$max": bson.M{
+			"last_login": arg1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update current date method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateUpdatedAtCurrentDateByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "UpdatedAt"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorCurrentDate,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg1,
+	}, bson.M{
+		"$currentDate": bson.M{
+			"updated_at": true,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update with positional operator",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateConsentHistoryDollarValueByIDAndConsentHistoryID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeBool),
+						createTypeVar(testutils.TypeObjectIDNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+								testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+							},
+							ParamIndex:      1,
+							Operator:        spec.UpdateOperatorSet,
+							Positional:      spec.PositionalFirst,
+							PositionalDepth: 1,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Operator: spec.OperatorAnd,
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+									testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 3,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"$and": []bson.M{
+			{
+				"_id": arg2,
+			},
+			{
+				"consent_history.id": arg3,
+			},
+		},
+	}, bson.M{
+		"$set": bson.M{
+			"consent_history.$.value": arg1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update with filtered positional operator",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateConsentHistoryElemValueByConsentHistoryValue",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeBool),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+								testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+							},
+							ParamIndex:      1,
+							Operator:        spec.UpdateOperatorSet,
+							Positional:      spec.PositionalFiltered,
+							PositionalDepth: 1,
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+									testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateMany(arg0, bson.M{
+		"consent_history.value": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"consent_history.$[elem].value": arg1,
+		},
+	}, options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{
+			bson.M{
+				"elem.value": arg2,
+			},
+		},
+	}))
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil`,
+		},
+		{
+			Name: "upsert with set on insert method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpsertCityAndCreatedAtSetOnInsertByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeTimeNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+							},
+							ParamIndex: 2,
+							Operator:   spec.UpdateOperatorSetOnInsert,
+						},
+					},
+					Mode:   spec.QueryModeOne,
+					Upsert: true,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 3,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg3,
+	}, bson.M{
+		"$set": bson.M{
+			"city": arg1,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": arg2,
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0 || result.UpsertedCount > 0, nil`,
+		},
+		{
+			Name: "upsert method with upserted ID return",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpsertCityByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(testutils.TypeObjectIDNamed),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode:           spec.QueryModeOne,
+					Upsert:         true,
+					UpsertedIDType: testutils.TypeObjectIDNamed,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	var upsertedID primitive.ObjectID
+	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"city": arg1,
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, upsertedID, err
+	}
+	if result.UpsertedID != nil {
+		upserted, ok := result.UpsertedID.(primitive.ObjectID)
+		if !ok {
+			return false, upsertedID, fmt.Errorf("%w: %T", ErrUserRepositoryInsertedIDTypeMismatch, result.UpsertedID)
+		}
+		upsertedID = upserted
+	}
+	return result.MatchedCount > 0 || result.UpsertedCount > 0, upsertedID, nil`,
+		},
+		{
+			Name: "upsert many method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpsertGenderByAge",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeGenderNamed),
+						createTypeVar(code.TypeInt),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+					Upsert: true,
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateMany(arg0, bson.M{
+		"age": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"gender": arg1,
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount + result.UpsertedCount), nil`,
+		},
+		{
+			Name: "update one method with modified result",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateAgeByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeInt),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode:           spec.QueryModeOne,
+					ReturnModified: true,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"age": arg1,
+		},
+	})
+	if err != nil {
+		return false, false, err
+	}
+	return result.MatchedCount > 0, result.ModifiedCount > 0, nil`,
+		},
+		{
+			Name: "update many method with modified result",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateCityByGender",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeGenderNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+					ReturnModified: true,
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateMany(arg0, bson.M{
+		"gender": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"city": arg1,
+		},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(result.MatchedCount), int(result.ModifiedCount), nil`,
 		},
 		{
 			Name: "simple update set and push method",
 			MethodSpec: spec.MethodSpec{
-				Name: "UpdateEnabledAndConsentHistoryPushByID",
+				Name: "UpdateEnabledAndConsentHistoryPushByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeBool),
+						createTypeVar(testutils.TypeConsentHistoryNamed),
+						createTypeVar(testutils.TypeGenderNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+							},
+							ParamIndex: 2,
+							Operator:   spec.UpdateOperatorPush,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 3,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg3,
+	}, bson.M{
+		"$push": bson.M{
+			"consent_history": arg2,
+		},
+		"$set": bson.M{
+			"enabled": arg1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update with deeply referenced field",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateNameFirstByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
+								testutils.FindStructFieldByName(testutils.TypeNameStruct, "First"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$set": bson.M{
+			"name.first": arg1,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update with patch struct",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdatePatchByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeUserPatchNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdatePatch{
+						spec.UpdatePatchField{
+							Name: "City",
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+							},
+						},
+						spec.UpdatePatchField{
+							Name: "Name",
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
+							},
+						},
+					},
+					Mode: spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	patchFields := bson.M{}
+	if arg1.City != nil {
+		patchFields["city"] = *arg1.City
+	}
+	if arg1.Name != nil {
+		patchFields["name"] = *arg1.Name
+	}
+	if len(patchFields) == 0 {
+		count, err := r.collection.CountDocuments(arg0, bson.M{
+			"_id": arg2,
+		})
+		if err != nil {
+			return false, err
+		}
+		return count > 0, nil
+	}
+	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$set": patchFields,
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update rename",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateRenamePhoneNumberToCityAll",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateRename{
+						From: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "PhoneNumber"),
+						},
+						To: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+						},
+					},
+					Mode: spec.QueryModeMany,
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateMany(arg0, bson.M{
+	}, bson.M{
+		"$rename": bson.M{
+			"phone_number": "city",
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil`,
+		},
+		{
+			Name: "update with query struct parameter",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateAgeByGender",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeInt),
+						createTypeVar(testutils.TypeUserQueryNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
+						spec.UpdateField{
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+							},
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+								},
+								Comparator:  spec.ComparatorEqual,
+								ParamIndex:  2,
+								ParamFields: []string{"Gender"},
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateMany(arg0, bson.M{
+		"gender": arg2.Gender,
+	}, bson.M{
+		"$set": bson.M{
+			"age": arg1,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+			expectedReceiver := codegen.MethodReceiver{
+				Name:     "r",
+				TypeName: "UserRepositoryMongo",
+				Pointer:  true,
+			}
+
+			params := testCase.MethodSpec.Signature.Params()
+			var expectedParamVars []*types.Var
+			for i := 0; i < params.Len(); i++ {
+				expectedParamVars = append(expectedParamVars, types.NewVar(token.NoPos, nil, fmt.Sprintf("arg%d", i),
+					params.At(i).Type()))
+			}
+			expectedParams := types.NewTuple(expectedParamVars...)
+			returns := testCase.MethodSpec.Signature.Results()
+			var expectedReturns []types.Type
+			for i := 0; i < returns.Len(); i++ {
+				expectedReturns = append(expectedReturns, returns.At(i).Type())
+			}
+
+			actual, err := generator.GenerateMethod(testCase.MethodSpec)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expectedReceiver != actual.Receiver {
+				t.Errorf(
+					"incorrect method receiver: expected %+v, got %+v",
+					expectedReceiver,
+					actual.Receiver,
+				)
+			}
+			if testCase.MethodSpec.Name != actual.Name {
+				t.Errorf(
+					"incorrect method name: expected %s, got %s",
+					testCase.MethodSpec.Name,
+					actual.Name,
+				)
+			}
+			if !reflect.DeepEqual(expectedParams, actual.Params) {
+				t.Errorf(
+					"incorrect struct params: expected %+v, got %+v",
+					expectedParams,
+					actual.Params,
+				)
+			}
+			if !reflect.DeepEqual(expectedReturns, actual.Returns) {
+				t.Errorf(
+					"incorrect struct returns: expected %+v, got %+v",
+					expectedReturns,
+					actual.Returns,
+				)
+			}
+			if err := testutils.ExpectMultiLineString(testCase.ExpectedBody, actual.Body.Code()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGenerateMethod_UpdateWithTimestamps(t *testing.T) {
+	testTable := []GenerateMethodTestCase{
+		{
+			Name: "update model method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateByID",
 				Signature: createSignature(
 					[]*types.Var{
 						createTypeVar(testutils.TypeContextNamed),
-						createTypeVar(code.TypeBool),
-						createTypeVar(testutils.TypeConsentHistoryNamed),
-						createTypeVar(testutils.TypeGenderNamed),
+						createTypeVar(types.NewPointer(testutils.TypeArticleNamed)),
+						createTypeVar(testutils.TypeObjectIDNamed),
 					},
 					[]*types.Var{
 						createTypeVar(code.TypeBool),
 						createTypeVar(code.TypeError),
 					},
 				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateModel{},
+					Mode:   spec.QueryModeOne,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeArticleStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	now := time.Now()
+	arg1.UpdatedAt = primitive.NewDateTimeFromTime(now)
+	result, err := r.collection.UpdateOne(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$set": arg1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil`,
+		},
+		{
+			Name: "update fields method",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateTitleByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(code.TypeString),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
 				Operation: spec.UpdateOperation{
 					Update: spec.UpdateFields{
 						spec.UpdateField{
 							FieldReference: spec.FieldReference{
-								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+								testutils.FindStructFieldByName(testutils.TypeArticleStruct, "Title"),
 							},
 							ParamIndex: 1,
 							Operator:   spec.UpdateOperatorSet,
 						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeArticleStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	result, err := r.collection.UpdateMany(arg0, bson.M{
+		"_id": arg2,
+	}, bson.M{
+		"$currentDate": bson.M{
+			"updated_at": true,
+		},
+		"$set": bson.M{
+			"title": arg1,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil`,
+		},
+		{
+			Name: "update fields method with updated timestamp field",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdateUpdatedAtByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeDateTimeNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeBool),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdateFields{
 						spec.UpdateField{
 							FieldReference: spec.FieldReference{
-								testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+								testutils.FindStructFieldByName(testutils.TypeArticleStruct, "UpdatedAt"),
 							},
-							ParamIndex: 2,
-							Operator:   spec.UpdateOperatorPush,
+							ParamIndex: 1,
+							Operator:   spec.UpdateOperatorSet,
 						},
 					},
 					Mode: spec.QueryModeOne,
@@ -299,23 +1595,20 @@ func TestGenerateMethod_Update(t *testing.T) {
 						Predicates: []spec.Predicate{
 							{
 								FieldReference: spec.FieldReference{
-									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+									testutils.FindStructFieldByName(testutils.TypeArticleStruct, "ID"),
 								},
 								Comparator: spec.ComparatorEqual,
-								ParamIndex: 3,
+								ParamIndex: 2,
 							},
 						},
 					},
 				},
 			},
 			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
-		"_id": arg3,
+		"_id": arg2,
 	}, bson.M{
-		"$push": bson.M{
-			"consent_history": arg2,
-		},
 		"$set": bson.M{
-			"enabled": arg1,
+			"updated_at": arg1,
 		},
 	})
 	if err != nil {
@@ -324,9 +1617,9 @@ func TestGenerateMethod_Update(t *testing.T) {
 	return result.MatchedCount > 0, nil`,
 		},
 		{
-			Name: "update with deeply referenced field",
+			Name: "upsert fields method",
 			MethodSpec: spec.MethodSpec{
-				Name: "UpdateNameFirstByID",
+				Name: "UpsertTitleByID",
 				Signature: createSignature(
 					[]*types.Var{
 						createTypeVar(testutils.TypeContextNamed),
@@ -342,8 +1635,7 @@ func TestGenerateMethod_Update(t *testing.T) {
 					Update: spec.UpdateFields{
 						spec.UpdateField{
 							FieldReference: spec.FieldReference{
-								testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
-								testutils.FindStructFieldByName(testutils.TypeNameStruct, "First"),
+								testutils.FindStructFieldByName(testutils.TypeArticleStruct, "Title"),
 							},
 							ParamIndex: 1,
 							Operator:   spec.UpdateOperatorSet,
@@ -354,84 +1646,105 @@ func TestGenerateMethod_Update(t *testing.T) {
 						Predicates: []spec.Predicate{
 							{
 								FieldReference: spec.FieldReference{
-									testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+									testutils.FindStructFieldByName(testutils.TypeArticleStruct, "ID"),
 								},
 								Comparator: spec.ComparatorEqual,
 								ParamIndex: 2,
 							},
 						},
 					},
+					Upsert: true,
 				},
 			},
 			ExpectedBody: `	result, err := r.collection.UpdateOne(arg0, bson.M{
 		"_id": arg2,
 	}, bson.M{
+		"$currentDate": bson.M{
+			"updated_at": true,
+		},
 		"$set": bson.M{
-			"name.first": arg1,
+			"title": arg1,
 		},
-	})
+		"$setOnInsert": bson.M{
+			"created_at": time.Now(),
+		},
+	}, options.Update().SetUpsert(true))
 	if err != nil {
 		return false, err
 	}
-	return result.MatchedCount > 0, nil`,
+	return result.MatchedCount > 0 || result.UpsertedCount > 0, nil`,
+		},
+		{
+			Name: "update with patch struct",
+			MethodSpec: spec.MethodSpec{
+				Name: "UpdatePatchByID",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeArticlePatchNamed),
+						createTypeVar(testutils.TypeObjectIDNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.UpdateOperation{
+					Update: spec.UpdatePatch{
+						spec.UpdatePatchField{
+							Name: "Title",
+							FieldReference: spec.FieldReference{
+								testutils.FindStructFieldByName(testutils.TypeArticleStruct, "Title"),
+							},
+						},
+					},
+					Mode: spec.QueryModeMany,
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeArticleStruct, "ID"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 2,
+							},
+						},
+					},
+					ReturnModified: true,
+				},
+			},
+			ExpectedBody: `	patchFields := bson.M{}
+	if arg1.Title != nil {
+		patchFields["title"] = *arg1.Title
+	}
+	update := bson.M{
+		"$currentDate": bson.M{
+			"updated_at": true,
+		},
+	}
+	if len(patchFields) > 0 {
+		update["$set"] = patchFields
+	}
+	result, err := r.collection.UpdateMany(arg0, bson.M{
+		"_id": arg2,
+	}, update)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(result.MatchedCount), int(result.ModifiedCount), nil`,
 		},
 	}
 
 	for _, testCase := range testTable {
 		t.Run(testCase.Name, func(t *testing.T) {
-			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
-			expectedReceiver := codegen.MethodReceiver{
-				Name:     "r",
-				TypeName: "UserRepositoryMongo",
-				Pointer:  true,
-			}
-
-			params := testCase.MethodSpec.Signature.Params()
-			var expectedParamVars []*types.Var
-			for i := 0; i < params.Len(); i++ {
-				expectedParamVars = append(expectedParamVars, types.NewVar(token.NoPos, nil, fmt.Sprintf("arg%d", i),
-					params.At(i).Type()))
-			}
-			expectedParams := types.NewTuple(expectedParamVars...)
-			returns := testCase.MethodSpec.Signature.Results()
-			var expectedReturns []types.Type
-			for i := 0; i < returns.Len(); i++ {
-				expectedReturns = append(expectedReturns, returns.At(i).Type())
-			}
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeArticleNamed, "ArticleRepository")
 
 			actual, err := generator.GenerateMethod(testCase.MethodSpec)
 
 			if err != nil {
 				t.Fatal(err)
 			}
-			if expectedReceiver != actual.Receiver {
-				t.Errorf(
-					"incorrect method receiver: expected %+v, got %+v",
-					expectedReceiver,
-					actual.Receiver,
-				)
-			}
-			if testCase.MethodSpec.Name != actual.Name {
-				t.Errorf(
-					"incorrect method name: expected %s, got %s",
-					testCase.MethodSpec.Name,
-					actual.Name,
-				)
-			}
-			if !reflect.DeepEqual(expectedParams, actual.Params) {
-				t.Errorf(
-					"incorrect struct params: expected %+v, got %+v",
-					expectedParams,
-					actual.Params,
-				)
-			}
-			if !reflect.DeepEqual(expectedReturns, actual.Returns) {
-				t.Errorf(
-					"incorrect struct returns: expected %+v, got %+v",
-					expectedReturns,
-					actual.Returns,
-				)
-			}
 			if err := testutils.ExpectMultiLineString(testCase.ExpectedBody, actual.Body.Code()); err != nil {
 				t.Error(err)
 			}
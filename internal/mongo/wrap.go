@@ -0,0 +1,184 @@
+package mongo
+
+import (
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"github.com/sunboyy/repogen/internal/code"
+	"github.com/sunboyy/repogen/internal/codegen"
+)
+
+// errDuplicateKey is returned when the operation violates a unique index.
+func (g RepositoryGenerator) errDuplicateKey() generatedError {
+	return generatedError{
+		Name:    "Err" + g.InterfaceName + "DuplicateKey",
+		Message: "duplicate key",
+	}
+}
+
+// errTimeout is returned when the operation times out.
+func (g RepositoryGenerator) errTimeout() generatedError {
+	return generatedError{
+		Name:    "Err" + g.InterfaceName + "Timeout",
+		Message: "timeout",
+	}
+}
+
+// errorClassification maps the condition of the driver error to the generated
+// sentinel error that it is classified into.
+type errorClassification struct {
+	Condition codegen.Statement
+	Error     generatedError
+}
+
+// errorClassifications returns the classifications of the driver errors in
+// the order that they are checked.
+func (g RepositoryGenerator) errorClassifications() []errorClassification {
+	return []errorClassification{
+		{
+			Condition: codegen.CallStatement{
+				FuncName: "errors.Is",
+				Params: codegen.StatementList{
+					codegen.Identifier("err"),
+					codegen.Identifier("mongo.ErrNoDocuments"),
+				},
+			},
+			Error: g.errNotFound(),
+		},
+		{
+			Condition: codegen.CallStatement{
+				FuncName: "mongo.IsDuplicateKeyError",
+				Params:   codegen.StatementList{codegen.Identifier("err")},
+			},
+			Error: g.errDuplicateKey(),
+		},
+		{
+			Condition: codegen.CallStatement{
+				FuncName: "mongo.IsTimeout",
+				Params:   codegen.StatementList{codegen.Identifier("err")},
+			},
+			Error: g.errTimeout(),
+		},
+	}
+}
+
+// GenerateErrorClassifier creates codegen.FunctionBuilder of a function that
+// wraps the driver errors with the generated sentinel errors. It is used by
+// the generated methods when the errors are wrapped.
+func (g RepositoryGenerator) GenerateErrorClassifier() codegen.FunctionBuilder {
+	var body codegen.FunctionBody
+	for _, classification := range g.errorClassifications() {
+		body = append(body, codegen.IfBlock{
+			Condition: []codegen.Statement{
+				classification.Condition,
+			},
+			Statements: []codegen.Statement{
+				codegen.ReturnStatement{
+					codegen.CallStatement{
+						FuncName: "fmt.Errorf",
+						Params: codegen.StatementList{
+							codegen.Identifier(`"%w: %w"`),
+							codegen.Identifier(classification.Error.Name),
+							codegen.Identifier("err"),
+						},
+					},
+				},
+			},
+		})
+	}
+	body = append(body, codegen.ReturnStatement{
+		codegen.Identifier("err"),
+	})
+
+	return codegen.FunctionBuilder{
+		Pkg:     g.targetPkg,
+		Name:    g.errorClassifierName(),
+		Params:  types.NewTuple(types.NewVar(token.NoPos, nil, "err", code.TypeError)),
+		Returns: []types.Type{code.TypeError},
+		Body:    body,
+	}
+}
+
+func (g RepositoryGenerator) errorClassifierName() string {
+	return "classify" + g.InterfaceName + "Error"
+}
+
+// wrapPrefix returns the prefix of the wrapped errors of the method, e.g.
+// `UserRepository.FindByCity: `.
+func (g RepositoryGenerator) wrapPrefix(methodName string) string {
+	return g.InterfaceName + "." + methodName + ": "
+}
+
+// wrapError generates a statement that wraps the driver error with the
+// repository and method name.
+func (g RepositoryGenerator) wrapError(methodName string) codegen.Statement {
+	return codegen.CallStatement{
+		FuncName: "fmt.Errorf",
+		Params: codegen.StatementList{
+			codegen.Identifier(strconv.Quote(g.wrapPrefix(methodName) + "%w")),
+			codegen.CallStatement{
+				FuncName: g.errorClassifierName(),
+				Params:   codegen.StatementList{codegen.Identifier("err")},
+			},
+		},
+	}
+}
+
+// wrapReturnedErrors replaces the driver error returned by the statements,
+// including the ones in the nested blocks, with the wrapped error. The
+// generated errors are prefixed in the same way as the wrapped error.
+func wrapReturnedErrors(stmts []codegen.Statement, prefix string,
+	wrappedErr codegen.Statement) []codegen.Statement {
+
+	wrappedStmts := make([]codegen.Statement, len(stmts))
+	for i, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case codegen.ReturnStatement:
+			wrappedReturn := make(codegen.ReturnStatement, len(stmt))
+			for j, value := range stmt {
+				switch typedValue := value.(type) {
+				case codegen.Identifier:
+					if typedValue == "err" {
+						value = wrappedErr
+					}
+				case idTypeMismatchError:
+					typedValue.Prefix = prefix
+					value = typedValue
+				}
+				wrappedReturn[j] = value
+			}
+			wrappedStmts[i] = wrappedReturn
+		case codegen.IfBlock:
+			stmt.Statements = wrapReturnedErrors(stmt.Statements, prefix, wrappedErr)
+			wrappedStmts[i] = stmt
+		case codegen.RawBlock:
+			stmt.Statements = wrapReturnedErrors(stmt.Statements, prefix, wrappedErr)
+			wrappedStmts[i] = stmt
+		default:
+			wrappedStmts[i] = stmt
+		}
+	}
+	return wrappedStmts
+}
+
+// idTypeMismatchError is the error returned when the database generates an ID
+// that is not of the type expected by the method. It reports the sentinel
+// error along with the actual type of the ID.
+type idTypeMismatchError struct {
+	Sentinel string
+	ID       string
+	// Prefix is prepended to the error message when the errors are wrapped.
+	Prefix string
+}
+
+func (err idTypeMismatchError) CodeLines() []string {
+	return codegen.CallStatement{
+		FuncName: "fmt.Errorf",
+		Params: codegen.StatementList{
+			codegen.Identifier(strconv.Quote(err.Prefix + "%w: %T")),
+			codegen.Identifier(err.Sentinel),
+			codegen.Identifier(err.ID),
+		},
+	}.CodeLines()
+}
@@ -0,0 +1,230 @@
+package mongo_test
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/sunboyy/repogen/internal/code"
+	"github.com/sunboyy/repogen/internal/mongo"
+	"github.com/sunboyy/repogen/internal/spec"
+	"github.com/sunboyy/repogen/internal/testutils"
+)
+
+func TestGenerateErrorClassifier(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	expectedBody := `	if errors.Is(err, mongo.ErrNoDocuments) {
+		return fmt.Errorf("%w: %w", ErrUserRepositoryNotFound, err)
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %w", ErrUserRepositoryDuplicateKey, err)
+	}
+	if mongo.IsTimeout(err) {
+		return fmt.Errorf("%w: %w", ErrUserRepositoryTimeout, err)
+	}
+	return err`
+
+	actual := generator.GenerateErrorClassifier()
+
+	if actual.Name != "classifyUserRepositoryError" {
+		t.Errorf("incorrect function name: expected classifyUserRepositoryError, got %s", actual.Name)
+	}
+	if actual.Params.Len() != 1 || actual.Params.At(0).Name() != "err" ||
+		actual.Params.At(0).Type() != code.TypeError {
+		t.Errorf("incorrect function params: expected (err error), got %s", actual.Params)
+	}
+	if !reflect.DeepEqual([]types.Type{code.TypeError}, actual.Returns) {
+		t.Errorf("incorrect function returns: expected [error], got %v", actual.Returns)
+	}
+	if err := testutils.ExpectMultiLineString(expectedBody, actual.Body.Code()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerateErrors_WrapErrors(t *testing.T) {
+	generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+	generator.WrapErrors = true
+	insertOneIDSpec := spec.MethodSpec{
+		Name: "InsertOneID",
+		Operation: spec.InsertOperation{
+			Mode:   spec.QueryModeOne,
+			IDType: testutils.TypeObjectIDNamed,
+		},
+	}
+	findOneSpec := spec.MethodSpec{
+		Name: "FindByID",
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeOne,
+		},
+		Doc: []string{"// repogen:notfound=sentinel"},
+	}
+
+	actual := generator.GenerateErrors([]spec.MethodSpec{insertOneIDSpec, findOneSpec})
+
+	var actualNames []string
+	for _, v := range actual.Vars {
+		actualNames = append(actualNames, v.Name)
+	}
+	expectedNames := []string{
+		"ErrUserRepositoryNotFound",
+		"ErrUserRepositoryDuplicateKey",
+		"ErrUserRepositoryTimeout",
+		"ErrUserRepositoryInsertedIDTypeMismatch",
+	}
+	if !reflect.DeepEqual(expectedNames, actualNames) {
+		t.Errorf("incorrect errors: expected %v, got %v", expectedNames, actualNames)
+	}
+}
+
+func TestGenerateMethod_WrapErrors(t *testing.T) {
+	findByIDSpec := spec.MethodSpec{
+		Name: "FindByID",
+		Signature: createSignature(
+			[]*types.Var{
+				createTypeVar(testutils.TypeContextNamed),
+				createTypeVar(testutils.TypeObjectIDNamed),
+			},
+			[]*types.Var{
+				createTypeVar(types.NewPointer(testutils.TypeUserNamed)),
+				createTypeVar(code.TypeError),
+			},
+		),
+		Operation: spec.FindOperation{
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{
+				Predicates: []spec.Predicate{
+					{
+						Comparator: spec.ComparatorEqual,
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+						},
+						ParamIndex: 1,
+					},
+				},
+			},
+		},
+	}
+	findByIDNilSpec := findByIDSpec
+	findByIDNilSpec.Doc = []string{"// repogen:notfound=nil"}
+
+	testTable := []GenerateMethodTestCase{
+		{
+			Name:       "find one method",
+			MethodSpec: findByIDSpec,
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		return nil, fmt.Errorf("UserRepository.FindByID: %w", classifyUserRepositoryError(err))
+	}
+	return &entity, nil`,
+		},
+		{
+			Name:       "find one method with not-found behavior",
+			MethodSpec: findByIDNilSpec,
+			ExpectedBody: `	findOptions := options.FindOne().SetSort(bson.M{
+	})
+	var entity User
+	if err := r.collection.FindOne(arg0, bson.M{
+		"_id": arg1,
+	}, findOptions).Decode(&entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("UserRepository.FindByID: %w", classifyUserRepositoryError(err))
+	}
+	return &entity, nil`,
+		},
+		{
+			Name: "count method",
+			MethodSpec: spec.MethodSpec{
+				Name: "CountByGender",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(testutils.TypeGenderNamed),
+					},
+					[]*types.Var{
+						createTypeVar(code.TypeInt),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.CountOperation{
+					Query: spec.QuerySpec{
+						Predicates: []spec.Predicate{
+							{
+								FieldReference: spec.FieldReference{
+									testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+								},
+								Comparator: spec.ComparatorEqual,
+								ParamIndex: 1,
+							},
+						},
+					},
+				},
+			},
+			ExpectedBody: `	count, err := r.collection.CountDocuments(arg0, bson.M{
+		"gender": arg1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("UserRepository.CountByGender: %w", classifyUserRepositoryError(err))
+	}
+	return int(count), nil`,
+		},
+		{
+			Name: "insert many method with typed inserted IDs",
+			MethodSpec: spec.MethodSpec{
+				Name: "InsertManyIDs",
+				Signature: createSignature(
+					[]*types.Var{
+						createTypeVar(testutils.TypeContextNamed),
+						createTypeVar(types.NewSlice(types.NewPointer(testutils.TypeUserNamed))),
+					},
+					[]*types.Var{
+						createTypeVar(types.NewSlice(testutils.TypeObjectIDNamed)),
+						createTypeVar(code.TypeError),
+					},
+				),
+				Operation: spec.InsertOperation{
+					Mode:   spec.QueryModeMany,
+					IDType: testutils.TypeObjectIDNamed,
+				},
+			},
+			ExpectedBody: `	var entities []interface{}
+	for _, model := range arg1 {
+		entities = append(entities, model)
+	}
+	result, err := r.collection.InsertMany(arg0, entities)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepository.InsertManyIDs: %w", classifyUserRepositoryError(err))
+	}
+	var insertedIDs []primitive.ObjectID
+	for _, id := range result.InsertedIDs {
+		insertedID, ok := id.(primitive.ObjectID)
+		if !ok {
+			return nil, fmt.Errorf("UserRepository.InsertManyIDs: %w: %T", ErrUserRepositoryInsertedIDTypeMismatch, id)
+		}
+		insertedIDs = append(insertedIDs, insertedID)
+	}
+	return insertedIDs, nil`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			generator := mongo.NewGenerator(testutils.Pkg, testutils.TypeUserNamed, "UserRepository")
+			generator.WrapErrors = true
+
+			actual, err := generator.GenerateMethod(testCase.MethodSpec)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutils.ExpectMultiLineString(testCase.ExpectedBody, actual.Body.Code()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
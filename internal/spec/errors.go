@@ -11,13 +11,19 @@ import (
 
 // parsing error constants
 var (
-	ErrQueryRequired        = errors.New("spec: query is required")
-	ErrInvalidParam         = errors.New("spec: parameters do not match the query")
-	ErrInvalidUpdateFields  = errors.New("spec: update fields are invalid")
-	ErrContextParamRequired = errors.New("spec: context parameter is required")
-	ErrLimitAmountRequired  = errors.New("spec: limit amount is required")
-	ErrLimitNonPositive     = errors.New("spec: limit value must be positive")
-	ErrLimitOnFindOne       = errors.New("spec: cannot specify limit on find one")
+	ErrQueryRequired            = errors.New("spec: query is required")
+	ErrInvalidParam             = errors.New("spec: parameters do not match the query")
+	ErrInvalidUpdateFields      = errors.New("spec: update fields are invalid")
+	ErrContextParamRequired     = errors.New("spec: context parameter is required")
+	ErrLimitAmountRequired      = errors.New("spec: limit amount is required")
+	ErrLimitNonPositive         = errors.New("spec: limit value must be positive")
+	ErrLimitOnFindOne           = errors.New("spec: cannot specify limit on find one")
+	ErrPushEachSliceNonPositive = errors.New("spec: push each slice value must be positive")
+	ErrMultipleFilteredArrays   = errors.New("spec: filtered positional operator can only be applied to one array")
+	ErrSetOnInsertWithoutUpsert = errors.New("spec: set on insert operator requires upsert operation")
+	ErrInvalidVariadicParam     = errors.New("spec: variadic parameter is only supported by In and NotIn comparators")
+	ErrMixedOptionalArguments   = errors.New("spec: arguments of a predicate must be either all pointers or all non-pointers")
+	ErrPointerPatchParam        = errors.New("spec: patch parameter must not be a pointer")
 )
 
 // NewUnsupportedReturnError creates unsupportedReturnError
@@ -162,3 +168,149 @@ func (err incompatibleUpdateOperatorError) Error() string {
 	return fmt.Sprintf("cannot use update operator %s with struct field '%s' of type '%s'",
 		err.UpdateOperator, err.ReferencingCode, err.ReferencedType.String())
 }
+
+// NewIncomparableFieldsError creates incomparableFieldsError
+func NewIncomparableFieldsError(fieldReference FieldReference, comparedFieldReference FieldReference) error {
+	return incomparableFieldsError{
+		ReferencingCode:         fieldReference.ReferencingCode(),
+		ReferencedType:          fieldReference.ReferencedField().Var.Type(),
+		ComparedReferencingCode: comparedFieldReference.ReferencingCode(),
+		ComparedReferencedType:  comparedFieldReference.ReferencedField().Var.Type(),
+	}
+}
+
+type incomparableFieldsError struct {
+	ReferencingCode         string
+	ReferencedType          types.Type
+	ComparedReferencingCode string
+	ComparedReferencedType  types.Type
+}
+
+func (err incomparableFieldsError) Error() string {
+	return fmt.Sprintf("cannot compare struct field '%s' of type '%s' with struct field '%s' of type '%s'",
+		err.ReferencingCode, err.ReferencedType.String(),
+		err.ComparedReferencingCode, err.ComparedReferencedType.String())
+}
+
+// NewIncompatibleRenameError creates incompatibleRenameError
+func NewIncompatibleRenameError(fromFieldReference FieldReference, toFieldReference FieldReference) error {
+	return incompatibleRenameError{
+		FromReferencingCode: fromFieldReference.ReferencingCode(),
+		FromReferencedType:  fromFieldReference.ReferencedField().Var.Type(),
+		ToReferencingCode:   toFieldReference.ReferencingCode(),
+		ToReferencedType:    toFieldReference.ReferencedField().Var.Type(),
+	}
+}
+
+type incompatibleRenameError struct {
+	FromReferencingCode string
+	FromReferencedType  types.Type
+	ToReferencingCode   string
+	ToReferencedType    types.Type
+}
+
+func (err incompatibleRenameError) Error() string {
+	return fmt.Sprintf("cannot rename struct field '%s' of type '%s' to struct field '%s' of type '%s'",
+		err.FromReferencingCode, err.FromReferencedType.String(),
+		err.ToReferencingCode, err.ToReferencedType.String())
+}
+
+// NewPositionalQueryRequiredError creates positionalQueryRequiredError
+func NewPositionalQueryRequiredError(fieldReference FieldReference) error {
+	return positionalQueryRequiredError{ReferencingCode: fieldReference.ReferencingCode()}
+}
+
+type positionalQueryRequiredError struct {
+	ReferencingCode string
+}
+
+func (err positionalQueryRequiredError) Error() string {
+	return fmt.Sprintf("positional update of struct field '%s' requires a query on that field", err.ReferencingCode)
+}
+
+// NewPositionalRequiredError creates positionalRequiredError
+func NewPositionalRequiredError(fieldReference FieldReference) error {
+	return positionalRequiredError{ReferencingCode: fieldReference.ReferencingCode()}
+}
+
+type positionalRequiredError struct {
+	ReferencingCode string
+}
+
+func (err positionalRequiredError) Error() string {
+	return fmt.Sprintf("update of struct field '%s' inside an array requires a positional operator",
+		err.ReferencingCode)
+}
+
+// NewOptionalPositionalQueryError creates optionalPositionalQueryError
+func NewOptionalPositionalQueryError(fieldReference FieldReference) error {
+	return optionalPositionalQueryError{ReferencingCode: fieldReference.ReferencingCode()}
+}
+
+type optionalPositionalQueryError struct {
+	ReferencingCode string
+}
+
+func (err optionalPositionalQueryError) Error() string {
+	return fmt.Sprintf("positional update of struct field '%s' requires a non-optional query on that field",
+		err.ReferencingCode)
+}
+
+// NewFieldComparisonArrayFilterError creates fieldComparisonArrayFilterError
+func NewFieldComparisonArrayFilterError(fieldReference FieldReference) error {
+	return fieldComparisonArrayFilterError{ReferencingCode: fieldReference.ReferencingCode()}
+}
+
+type fieldComparisonArrayFilterError struct {
+	ReferencingCode string
+}
+
+func (err fieldComparisonArrayFilterError) Error() string {
+	return fmt.Sprintf("filtered positional update of struct field '%s' does not support field comparison",
+		err.ReferencingCode)
+}
+
+// NewOptionalPredicateNotSupportedError creates
+// optionalPredicateNotSupportedError.
+func NewOptionalPredicateNotSupportedError(operationName string, fieldReference FieldReference) error {
+	return optionalPredicateNotSupportedError{
+		OperationName:   operationName,
+		ReferencingCode: fieldReference.ReferencingCode(),
+	}
+}
+
+type optionalPredicateNotSupportedError struct {
+	OperationName   string
+	ReferencingCode string
+}
+
+func (err optionalPredicateNotSupportedError) Error() string {
+	return fmt.Sprintf("%s operation does not support optional query on struct field '%s'",
+		err.OperationName, err.ReferencingCode)
+}
+
+// NewPatchFieldNotPointerError creates patchFieldNotPointerError
+func NewPatchFieldNotPointerError(fieldName string) error {
+	return patchFieldNotPointerError{FieldName: fieldName}
+}
+
+type patchFieldNotPointerError struct {
+	FieldName string
+}
+
+func (err patchFieldNotPointerError) Error() string {
+	return fmt.Sprintf("patch field '%s' must be a pointer", err.FieldName)
+}
+
+// NewQueryStructFieldNotFoundError creates queryStructFieldNotFoundError
+func NewQueryStructFieldNotFoundError(fieldName string) error {
+	return queryStructFieldNotFoundError{FieldName: fieldName}
+}
+
+type queryStructFieldNotFoundError struct {
+	FieldName string
+}
+
+func (err queryStructFieldNotFoundError) Error() string {
+	return fmt.Sprintf("query struct field '%s' not found", err.FieldName)
+}
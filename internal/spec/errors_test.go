@@ -22,6 +22,11 @@ func TestError(t *testing.T) {
 			Error:          spec.NewUnknownOperationError("Search"),
 			ExpectedString: "unknown operation 'Search'",
 		},
+		{
+			Name:           "QueryStructFieldNotFoundError",
+			Error:          spec.NewQueryStructFieldNotFoundError("AgeFrom"),
+			ExpectedString: "query struct field 'AgeFrom' not found",
+		},
 		{
 			Name:           "StructFieldNotFoundError",
 			Error:          spec.NewStructFieldNotFoundError([]string{"Phone", "Number"}),
@@ -69,6 +74,85 @@ func TestError(t *testing.T) {
 			}),
 			ExpectedString: "cannot use update operator INC with struct field 'City' of type 'string'",
 		},
+		{
+			Name: "IncomparableFieldsError",
+			Error: spec.NewIncomparableFieldsError(spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "City", code.TypeString),
+				},
+			}, spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "Age", code.TypeInt),
+				},
+			}),
+			ExpectedString: "cannot compare struct field 'City' of type 'string' with struct field 'Age' of type 'int'",
+		},
+		{
+			Name: "IncompatibleRenameError",
+			Error: spec.NewIncompatibleRenameError(spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "City", code.TypeString),
+				},
+			}, spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "Age", code.TypeInt),
+				},
+			}),
+			ExpectedString: "cannot rename struct field 'City' of type 'string' to struct field 'Age' of type 'int'",
+		},
+		{
+			Name: "PositionalQueryRequiredError",
+			Error: spec.NewPositionalQueryRequiredError(spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "ConsentHistory", types.NewSlice(code.TypeString)),
+				},
+			}),
+			ExpectedString: "positional update of struct field 'ConsentHistory' requires a query on that field",
+		},
+		{
+			Name: "PositionalRequiredError",
+			Error: spec.NewPositionalRequiredError(spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "ConsentHistory", types.NewSlice(code.TypeString)),
+				},
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "Value", code.TypeBool),
+				},
+			}),
+			ExpectedString: "update of struct field 'ConsentHistory.Value' inside an array requires a positional operator",
+		},
+		{
+			Name: "OptionalPositionalQueryError",
+			Error: spec.NewOptionalPositionalQueryError(spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "ConsentHistory", types.NewSlice(code.TypeString)),
+				},
+			}),
+			ExpectedString: "positional update of struct field 'ConsentHistory' requires a non-optional query on that field",
+		},
+		{
+			Name: "FieldComparisonArrayFilterError",
+			Error: spec.NewFieldComparisonArrayFilterError(spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "ConsentHistory", types.NewSlice(code.TypeString)),
+				},
+			}),
+			ExpectedString: "filtered positional update of struct field 'ConsentHistory' does not support field comparison",
+		},
+		{
+			Name: "OptionalPredicateNotSupportedError",
+			Error: spec.NewOptionalPredicateNotSupportedError("Delete", spec.FieldReference{
+				code.StructField{
+					Var: types.NewVar(token.NoPos, nil, "City", code.TypeString),
+				},
+			}),
+			ExpectedString: "Delete operation does not support optional query on struct field 'City'",
+		},
+		{
+			Name:           "PatchFieldNotPointerError",
+			Error:          spec.NewPatchFieldNotPointerError("City"),
+			ExpectedString: "patch field 'City' must be a pointer",
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -26,6 +26,30 @@ func (r FieldReference) ReferencingCode() string {
 	return strings.Join(fieldNames, ".")
 }
 
+// HasPrefix returns true if the reference path begins with the given prefix
+func (r FieldReference) HasPrefix(prefix FieldReference) bool {
+	if len(r) < len(prefix) {
+		return false
+	}
+	for i, field := range prefix {
+		if r[i].Var != field.Var {
+			return false
+		}
+	}
+	return true
+}
+
+// crossesArray returns true if the reference path goes through an array
+// field before reaching the referenced field, e.g. ConsentHistory.Value.
+func (r FieldReference) crossesArray() bool {
+	for i := 0; i < len(r)-1; i++ {
+		if _, ok := r[i].Var.Type().Underlying().(*types.Slice); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func resolveStructField(structModel *types.Struct, tokens []string) (FieldReference, bool) {
 	fieldName := strings.Join(tokens, "")
 	for i := 0; i < structModel.NumFields(); i++ {
@@ -88,6 +112,9 @@ func getUnderlyingStructType(t types.Type) (*types.Struct, bool) {
 	case *types.Pointer:
 		return getUnderlyingStructType(t.Elem())
 
+	case *types.Slice:
+		return getUnderlyingStructType(t.Elem())
+
 	default:
 		return nil, false
 	}
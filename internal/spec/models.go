@@ -16,6 +16,9 @@ type MethodSpec struct {
 	Name      string
 	Signature *types.Signature
 	Operation Operation
+	// Doc is the lines of the doc comment of the interface method including
+	// the comment markers. It is empty if the doc comment is not available.
+	Doc []string
 }
 
 // Operation is an interface for any kind of operation
@@ -26,6 +29,15 @@ type Operation interface {
 // InsertOperation is a method specification for insert operations
 type InsertOperation struct {
 	Mode QueryMode
+	// IDType is the type of the inserted IDs returned by the method. Nil means
+	// that the inserted IDs are returned as interface{}.
+	IDType types.Type
+	// ReturnModel returns the inserted models with the inserted IDs written
+	// to the ID field instead of the inserted IDs.
+	ReturnModel bool
+	// ValueModel accepts the models by value (i.e. []Model) instead of
+	// pointers to the models.
+	ValueModel bool
 }
 
 // Name returns "Insert" operation name
@@ -39,6 +51,9 @@ type FindOperation struct {
 	Query QuerySpec
 	Sorts []Sort
 	Limit int
+	// ValueModel returns the models by value (i.e. Model or []Model) instead
+	// of pointers to the models.
+	ValueModel bool
 }
 
 // Name returns "Find" operation name
@@ -46,7 +61,8 @@ func (o FindOperation) Name() string {
 	return "Find"
 }
 
-// Sort is a detail of sorting find result
+// Sort is a detail of sorting find result. Sorting by text search score does
+// not refer to any field so its FieldReference is left empty.
 type Sort struct {
 	FieldReference FieldReference
 	Ordering       Ordering
@@ -59,6 +75,7 @@ type Ordering string
 const (
 	OrderingAscending  = "ASC"
 	OrderingDescending = "DESC"
+	OrderingTextScore  = "TEXT_SCORE"
 )
 
 // DeleteOperation is a method specification for delete operations
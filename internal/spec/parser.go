@@ -51,7 +51,9 @@ func (p interfaceMethodParser) parseMethod() (Operation, error) {
 	case "Find":
 		return p.parseFindOperation(methodNameTokens[1:])
 	case "Update":
-		return p.parseUpdateOperation(methodNameTokens[1:])
+		return p.parseUpdateOperation(methodNameTokens[1:], false)
+	case "Upsert":
+		return p.parseUpdateOperation(methodNameTokens[1:], true)
 	case "Delete":
 		return p.parseDeleteOperation(methodNameTokens[1:])
 	case "Count":
@@ -61,7 +63,7 @@ func (p interfaceMethodParser) parseMethod() (Operation, error) {
 }
 
 func (p interfaceMethodParser) parseInsertOperation(tokens []string) (Operation, error) {
-	mode, err := p.extractInsertReturns(p.Signature.Results())
+	operation, err := p.extractInsertReturns(p.Signature.Results())
 	if err != nil {
 		return nil, err
 	}
@@ -71,47 +73,78 @@ func (p interfaceMethodParser) parseInsertOperation(tokens []string) (Operation,
 	}
 
 	pointerType := types.NewPointer(p.NamedStruct)
-	if mode == QueryModeOne && !types.Identical(p.Signature.Params().At(1).Type(), pointerType) {
+	if operation.Mode == QueryModeOne && !types.Identical(p.Signature.Params().At(1).Type(), pointerType) {
 		return nil, ErrInvalidParam
 	}
 
-	arrayType := types.NewSlice(pointerType)
-	if mode == QueryModeMany && !types.Identical(p.Signature.Params().At(1).Type(), arrayType) {
-		return nil, ErrInvalidParam
+	if operation.Mode == QueryModeMany {
+		paramType := p.Signature.Params().At(1).Type()
+		switch {
+		case types.Identical(paramType, types.NewSlice(pointerType)):
+		case types.Identical(paramType, types.NewSlice(p.NamedStruct)):
+			operation.ValueModel = true
+		default:
+			return nil, ErrInvalidParam
+		}
+
+		// the inserted models are returned as they are given
+		if operation.ReturnModel && !types.Identical(paramType, p.Signature.Results().At(0).Type()) {
+			return nil, ErrInvalidParam
+		}
 	}
 
-	return InsertOperation{
-		Mode: mode,
-	}, nil
+	return operation, nil
 }
 
-func (p interfaceMethodParser) extractInsertReturns(returns *types.Tuple) (QueryMode, error) {
+// extractInsertReturns extracts the query mode and the type of the returned
+// values from the returns of the insert method. The inserted IDs can be
+// returned as interface{} or as the type of the model field with `_id` bson
+// tag. The inserted models can also be returned, in which case the inserted IDs
+// are written back to that field.
+func (p interfaceMethodParser) extractInsertReturns(returns *types.Tuple) (InsertOperation, error) {
 	if returns.Len() != 2 {
-		return "", NewOperationReturnCountUnmatchedError(2)
+		return InsertOperation{}, NewOperationReturnCountUnmatchedError(2)
 	}
 
 	if !types.Identical(returns.At(1).Type(), code.TypeError) {
-		return "", NewUnsupportedReturnError(returns.At(1).Type(), 1)
+		return InsertOperation{}, NewUnsupportedReturnError(returns.At(1).Type(), 1)
 	}
 
+	idField, hasIDField := code.FindIDField(p.UnderlyingStruct)
+	modelType := types.NewPointer(p.NamedStruct)
+
 	switch t := returns.At(0).Type().(type) {
 	case *types.Interface:
 		if t.Empty() {
-			return QueryModeOne, nil
+			return InsertOperation{Mode: QueryModeOne}, nil
 		}
 
 	case *types.Slice:
 		interfaceType, ok := t.Elem().(*types.Interface)
 		if ok && interfaceType.Empty() {
-			return QueryModeMany, nil
+			return InsertOperation{Mode: QueryModeMany}, nil
+		}
+		if hasIDField && types.Identical(t.Elem(), idField.Var.Type()) {
+			return InsertOperation{Mode: QueryModeMany, IDType: t.Elem()}, nil
+		}
+		if hasIDField && (types.Identical(t.Elem(), modelType) || types.Identical(t.Elem(), p.NamedStruct)) {
+			return InsertOperation{Mode: QueryModeMany, ReturnModel: true}, nil
+		}
+
+	default:
+		if hasIDField && types.Identical(t, idField.Var.Type()) {
+			return InsertOperation{Mode: QueryModeOne, IDType: t}, nil
+		}
+		if hasIDField && types.Identical(t, modelType) {
+			return InsertOperation{Mode: QueryModeOne, ReturnModel: true}, nil
 		}
 	}
 
-	return "", NewUnsupportedReturnError(returns.At(0).Type(), 0)
+	return InsertOperation{}, NewUnsupportedReturnError(returns.At(0).Type(), 0)
 }
 
 func (p interfaceMethodParser) parseFindOperation(tokens []string) (Operation, error) {
-	mode, err := p.extractModelOrSliceReturns(p.Signature.Results())
+	mode, valueModel, err := p.extractModelOrSliceReturns(p.Signature.Results())
 	if err != nil {
 		return nil, err
 	}
@@ -131,20 +164,22 @@ func (p interfaceMethodParser) parseFindOperation(tokens []string) (Operation, e
 		return nil, err
 	}
 
-	sorts, err := p.parseSort(sortTokens)
+	sorts, err := p.parseSort(sortTokens, querySpec.HasTextSearch())
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.validateQueryOnlyParams(querySpec); err != nil {
+	querySpec, err = p.bindQueryOnlyParams(querySpec)
+	if err != nil {
 		return nil, err
 	}
 
 	return FindOperation{
-		Mode:  mode,
-		Query: querySpec,
-		Sorts: sorts,
-		Limit: limit,
+		Mode:       mode,
+		Query:      querySpec,
+		Sorts:      sorts,
+		Limit:      limit,
+		ValueModel: valueModel,
 	}, nil
 }
 
@@ -170,7 +205,7 @@ func (p interfaceMethodParser) parseFindTop(tokens []string) (int, []string,
 	return 0, tokens, nil
 }
 
-func (p interfaceMethodParser) parseSort(rawTokens []string) ([]Sort, error) {
+func (p interfaceMethodParser) parseSort(rawTokens []string, textSearch bool) ([]Sort, error) {
 	if len(rawTokens) == 0 {
 		return nil, nil
 	}
@@ -182,7 +217,7 @@ func (p interfaceMethodParser) parseSort(rawTokens []string) ([]Sort, error) {
 
 	var sorts []Sort
 	for _, token := range sortTokens {
-		sort, err := p.parseSortToken(token)
+		sort, err := p.parseSortToken(token, textSearch)
 		if err != nil {
 			return nil, err
 		}
@@ -192,7 +227,10 @@ func (p interfaceMethodParser) parseSort(rawTokens []string) ([]Sort, error) {
 	return sorts, nil
 }
 
-func (p interfaceMethodParser) parseSortToken(t []string) (Sort, error) {
+func (p interfaceMethodParser) parseSortToken(t []string, textSearch bool) (Sort, error) {
+	if textSearch && len(t) == 1 && t[0] == "Score" {
+		return Sort{Ordering: OrderingTextScore}, nil
+	}
 	if len(t) > 1 && t[len(t)-1] == "Asc" {
 		return p.createSort(t[:len(t)-1], OrderingAscending)
 	}
@@ -230,31 +268,42 @@ func (p interfaceMethodParser) splitQueryAndSortTokens(tokens []string) ([]strin
 	return queryTokens, sortTokens
 }
 
-func (p interfaceMethodParser) extractModelOrSliceReturns(returns *types.Tuple) (QueryMode, error) {
+// extractModelOrSliceReturns extracts the query mode from the returns of the
+// method. It also reports whether the models are returned by value (i.e.
+// Model or []Model) instead of pointers to the models.
+func (p interfaceMethodParser) extractModelOrSliceReturns(returns *types.Tuple) (QueryMode, bool, error) {
 	if returns.Len() != 2 {
-		return "", NewOperationReturnCountUnmatchedError(2)
+		return "", false, NewOperationReturnCountUnmatchedError(2)
 	}
 
 	if !types.Identical(returns.At(1).Type(), code.TypeError) {
-		return "", NewUnsupportedReturnError(returns.At(1).Type(), 1)
+		return "", false, NewUnsupportedReturnError(returns.At(1).Type(), 1)
 	}
 
 	switch t := returns.At(0).Type().(type) {
 	case *types.Pointer:
 		if types.Identical(t.Elem(), p.NamedStruct) {
-			return QueryModeOne, nil
+			return QueryModeOne, false, nil
 		}
 
 	case *types.Slice:
 		pointerType, ok := t.Elem().(*types.Pointer)
 		if ok {
 			if types.Identical(pointerType.Elem(), p.NamedStruct) {
-				return QueryModeMany, nil
+				return QueryModeMany, false, nil
 			}
 		}
+		if types.Identical(t.Elem(), p.NamedStruct) {
+			return QueryModeMany, true, nil
+		}
+
+	default:
+		if types.Identical(t, p.NamedStruct) {
+			return QueryModeOne, true, nil
+		}
 	}
 
-	return "", NewUnsupportedReturnError(returns.At(0).Type(), 0)
+	return "", false, NewUnsupportedReturnError(returns.At(0).Type(), 0)
 }
 
 func splitByAnd(tokens []string) ([][]string, bool) {
@@ -284,7 +333,9 @@ func (p interfaceMethodParser) splitUpdateAndQueryTokens(tokens []string) ([]str
 	var queryTokens []string
 
 	for i, token := range tokens {
-		if token == "By" || token == "All" {
+		// All is also a part of PullAll operator so it is treated as a query
+		// only when it is the last token.
+		if token == "By" || (token == "All" && i == len(tokens)-1) {
 			queryTokens = tokens[i:]
 			break
 		} else {
@@ -306,14 +357,19 @@ func (p interfaceMethodParser) parseDeleteOperation(tokens []string) (Operation,
 		return nil, err
 	}
 
-	if err := p.validateQueryOnlyParams(querySpec); err != nil {
+	querySpec, err = p.bindQueryOnlyParams(querySpec)
+	if err != nil {
 		return nil, err
 	}
 
-	return DeleteOperation{
+	operation := DeleteOperation{
 		Mode:  mode,
 		Query: querySpec,
-	}, nil
+	}
+	if err := querySpec.validateRequiredPredicates(operation.Name()); err != nil {
+		return nil, err
+	}
+	return operation, nil
 }
 
 func (p interfaceMethodParser) parseCountOperation(tokens []string) (Operation, error) {
@@ -326,7 +382,8 @@ func (p interfaceMethodParser) parseCountOperation(tokens []string) (Operation,
 		return nil, err
 	}
 
-	if err := p.validateQueryOnlyParams(querySpec); err != nil {
+	querySpec, err = p.bindQueryOnlyParams(querySpec)
+	if err != nil {
 		return nil, err
 	}
 
@@ -360,29 +417,37 @@ func (p interfaceMethodParser) extractIntOrBoolReturns(returns *types.Tuple) (Qu
 		return "", NewUnsupportedReturnError(returns.At(1).Type(), 1)
 	}
 
-	basicType, ok := returns.At(0).Type().(*types.Basic)
+	mode, ok := intOrBoolQueryMode(returns.At(0).Type())
+	if !ok {
+		return "", NewUnsupportedReturnError(returns.At(0).Type(), 0)
+	}
+
+	return mode, nil
+}
+
+// intOrBoolQueryMode determines the query mode from the return type. bool
+// return type refers to single-entity mode while int return type refers to
+// multiple-entity mode.
+func intOrBoolQueryMode(t types.Type) (QueryMode, bool) {
+	basicType, ok := t.(*types.Basic)
 	if ok {
 		if types.Identical(basicType, code.TypeBool) {
-			return QueryModeOne, nil
+			return QueryModeOne, true
 		}
 		if types.Identical(basicType, code.TypeInt) {
-			return QueryModeMany, nil
+			return QueryModeMany, true
 		}
 	}
 
-	return "", NewUnsupportedReturnError(returns.At(0).Type(), 0)
+	return "", false
 }
 
-func (p interfaceMethodParser) validateQueryOnlyParams(querySpec QuerySpec) error {
+func (p interfaceMethodParser) bindQueryOnlyParams(querySpec QuerySpec) (QuerySpec, error) {
 	if err := p.validateContextParam(); err != nil {
-		return err
-	}
-
-	if err := p.validateQueryFromParams(p.Signature.Params(), 1, querySpec); err != nil {
-		return err
+		return QuerySpec{}, err
 	}
 
-	return nil
+	return p.bindQueryParams(p.Signature.Params(), 1, querySpec)
 }
 
 func (p interfaceMethodParser) validateContextParam() error {
@@ -392,33 +457,201 @@ func (p interfaceMethodParser) validateContextParam() error {
 	return nil
 }
 
-func (p interfaceMethodParser) validateQueryFromParams(params *types.Tuple, startIndex int, querySpec QuerySpec) error {
+// bindQueryParams binds the arguments of the query to the method parameters
+// starting from startIndex. The arguments are bound to the positional
+// parameters, or to the fields of the query struct parameter if the query is
+// given as a single struct parameter instead.
+func (p interfaceMethodParser) bindQueryParams(params *types.Tuple, startIndex int,
+	querySpec QuerySpec) (QuerySpec, error) {
+
+	positionalQuerySpec, err := p.bindPositionalParams(params, startIndex, querySpec)
+	if err == nil {
+		return positionalQuerySpec, nil
+	}
+
+	if params.Len()-startIndex != 1 {
+		return QuerySpec{}, err
+	}
+	queryStruct, ok := params.At(startIndex).Type().Underlying().(*types.Struct)
+	if !ok || !hasQueryStructField(queryStruct, querySpec) {
+		return QuerySpec{}, err
+	}
+
+	return p.bindQueryStructFields(queryStruct, startIndex, querySpec)
+}
+
+// hasQueryStructField returns true if the struct has any field that holds the
+// arguments of the query. Otherwise, the struct is an ordinary positional
+// parameter of a mismatched type rather than a query struct.
+func hasQueryStructField(queryStruct *types.Struct, querySpec QuerySpec) bool {
+	for _, predicate := range querySpec.Predicates {
+		if predicate.NumberOfArguments() == 0 {
+			continue
+		}
+		for _, name := range predicate.queryStructFieldNames() {
+			if _, ok := resolveFieldByName(queryStruct, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bindQueryStructFields binds the arguments of each predicate to the fields of
+// the query struct parameter by their names. A predicate whose fields are
+// pointers to the required type becomes optional.
+func (p interfaceMethodParser) bindQueryStructFields(queryStruct *types.Struct, paramIndex int,
+	querySpec QuerySpec) (QuerySpec, error) {
+
+	var predicates []Predicate
+	for _, predicate := range querySpec.Predicates {
+		requiredType, _, err := p.predicateArgumentType(predicate)
+		if err != nil {
+			return QuerySpec{}, err
+		}
+		if predicate.NumberOfArguments() == 0 {
+			predicates = append(predicates, predicate)
+			continue
+		}
+
+		predicate.ParamIndex = paramIndex
+		predicate.ParamFields = predicate.queryStructFieldNames()
+		for i, name := range predicate.ParamFields {
+			field, ok := resolveFieldByName(queryStruct, name)
+			if !ok {
+				return QuerySpec{}, NewQueryStructFieldNotFoundError(name)
+			}
+
+			optional, err := p.matchOptionalArgumentType(name, field.ReferencedField().Var.Type(),
+				requiredType)
+			if err != nil {
+				return QuerySpec{}, err
+			}
+			if i > 0 && optional != predicate.Optional {
+				return QuerySpec{}, ErrMixedOptionalArguments
+			}
+			predicate.Optional = optional
+		}
+
+		predicates = append(predicates, predicate)
+	}
+
+	querySpec.Predicates = predicates
+	return querySpec, nil
+}
+
+// predicateArgumentType returns the type and the name of the arguments required
+// by the predicate after validating that the comparator can be applied to the
+// queried field.
+func (p interfaceMethodParser) predicateArgumentType(predicate Predicate) (types.Type, string, error) {
+	if predicate.Comparator == ComparatorTextSearch {
+		return code.TypeString, "TextSearch", nil
+	}
+
+	field := predicate.FieldReference.ReferencedField()
+	if !p.validateComparator(field.Var.Type(), predicate.Comparator) {
+		return nil, "", NewIncompatibleComparatorError(predicate.Comparator, field)
+	}
+
+	return predicate.Comparator.ArgumentTypeFromFieldType(field.Var.Type()),
+		predicate.FieldReference.ReferencingCode(), nil
+}
+
+// matchOptionalArgumentType checks whether the given argument type matches the
+// required type, either directly or as a pointer to the required type. It
+// returns true if the argument is a pointer, i.e. the argument is optional.
+func (p interfaceMethodParser) matchOptionalArgumentType(name string, givenType types.Type,
+	requiredType types.Type) (bool, error) {
+
+	if p.isArgumentTypeMatched(givenType, requiredType) {
+		return false, nil
+	}
+
+	pointerType, ok := givenType.(*types.Pointer)
+	if ok && p.isArgumentTypeMatched(pointerType.Elem(), requiredType) {
+		return true, nil
+	}
+
+	return false, NewArgumentTypeNotMatchedError(name, requiredType, givenType)
+}
+
+// bindPositionalParams binds the arguments of the query to the positional
+// parameters starting from startIndex. A predicate whose parameters are
+// pointers to the required type becomes optional.
+func (p interfaceMethodParser) bindPositionalParams(params *types.Tuple, startIndex int,
+	querySpec QuerySpec) (QuerySpec, error) {
+
 	if params.Len()-startIndex != querySpec.NumberOfArguments() {
-		return ErrInvalidParam
+		return QuerySpec{}, ErrInvalidParam
 	}
 
+	var predicates []Predicate
 	currentParamIndex := startIndex
 	for _, predicate := range querySpec.Predicates {
-		if (predicate.Comparator == ComparatorTrue || predicate.Comparator == ComparatorFalse) &&
-			!types.Identical(predicate.FieldReference.ReferencedField().Var.Type(), code.TypeBool) {
-			return NewIncompatibleComparatorError(predicate.Comparator,
-				predicate.FieldReference.ReferencedField())
+		requiredType, argumentName, err := p.predicateArgumentType(predicate)
+		if err != nil {
+			return QuerySpec{}, err
 		}
 
-		for i := 0; i < predicate.Comparator.NumberOfArguments(); i++ {
-			requiredType := predicate.Comparator.ArgumentTypeFromFieldType(
-				predicate.FieldReference.ReferencedField().Var.Type(),
-			)
+		for i := 0; i < predicate.NumberOfArguments(); i++ {
+			if p.isVariadicParam(params, currentParamIndex) &&
+				predicate.Comparator != ComparatorIn && predicate.Comparator != ComparatorNotIn {
+				return QuerySpec{}, ErrInvalidVariadicParam
+			}
 
-			if !types.Identical(params.At(currentParamIndex).Type(), requiredType) {
-				return NewArgumentTypeNotMatchedError(predicate.FieldReference.ReferencingCode(), requiredType,
-					params.At(currentParamIndex).Type())
+			optional, err := p.matchOptionalArgumentType(argumentName,
+				params.At(currentParamIndex).Type(), requiredType)
+			if err != nil {
+				return QuerySpec{}, err
+			}
+			if i > 0 && optional != predicate.Optional {
+				return QuerySpec{}, ErrMixedOptionalArguments
 			}
+			predicate.Optional = optional
 			currentParamIndex++
 		}
+
+		predicates = append(predicates, predicate)
 	}
 
-	return nil
+	querySpec.Predicates = predicates
+	return querySpec, nil
+}
+
+// isVariadicParam checks whether the parameter at the given index is a
+// variadic parameter. Its type is a slice of the element type.
+func (p interfaceMethodParser) isVariadicParam(params *types.Tuple, index int) bool {
+	return p.Signature.Variadic() && index == params.Len()-1
+}
+
+func (p interfaceMethodParser) validateComparator(referencedType types.Type, comparator Comparator) bool {
+	switch comparator {
+	case ComparatorTrue, ComparatorFalse:
+		return types.Identical(referencedType, code.TypeBool)
+
+	case ComparatorBefore, ComparatorAfter, ComparatorWithinLast:
+		return code.IsTime(referencedType)
+
+	case ComparatorIsNull, ComparatorIsNotNull:
+		switch t := referencedType.(type) {
+		case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+			return true
+
+		case *types.Named:
+			return p.validateComparator(t.Underlying(), comparator)
+
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (p interfaceMethodParser) isArgumentTypeMatched(givenType types.Type, requiredType types.Type) bool {
+	if requiredType == code.TypeDuration {
+		return givenType.String() == requiredType.String()
+	}
+	return types.Identical(givenType, requiredType)
 }
 
 func (p interfaceMethodParser) parseQuery(queryTokens []string, paramIndex int) (QuerySpec, error) {
@@ -19,10 +19,41 @@ func TestParseInterfaceMethod_Insert(t *testing.T) {
 		spec.InsertOperation{
 			Mode: spec.QueryModeMany,
 		},
+		// InsertManyIDs
+		spec.InsertOperation{
+			Mode:   spec.QueryModeMany,
+			IDType: testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID").Var.Type(),
+		},
+		// InsertManyModels
+		spec.InsertOperation{
+			Mode:        spec.QueryModeMany,
+			ReturnModel: true,
+		},
+		// InsertManyValueModels
+		spec.InsertOperation{
+			Mode:        spec.QueryModeMany,
+			ReturnModel: true,
+			ValueModel:  true,
+		},
+		// InsertManyValues
+		spec.InsertOperation{
+			Mode:       spec.QueryModeMany,
+			ValueModel: true,
+		},
 		// InsertOne
 		spec.InsertOperation{
 			Mode: spec.QueryModeOne,
 		},
+		// InsertOneID
+		spec.InsertOperation{
+			Mode:   spec.QueryModeOne,
+			IDType: testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID").Var.Type(),
+		},
+		// InsertOneModel
+		spec.InsertOperation{
+			Mode:        spec.QueryModeOne,
+			ReturnModel: true,
+		},
 	}
 
 	for i := 0; i < repoIntf.NumMethods(); i++ {
@@ -81,6 +112,30 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			}},
 		},
+		// FindByAgeGreaterThanAndCity
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Operator: spec.OperatorAnd,
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+						},
+						Comparator: spec.ComparatorGreaterThan,
+						ParamIndex: 1,
+						Optional:   true,
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 2,
+					},
+				},
+			},
+		},
 		// FindByAgeGreaterThanEqual
 		spec.FindOperation{
 			Mode: spec.QueryModeMany,
@@ -120,6 +175,20 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			}},
 		},
+		// FindByAgeNotBetween
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+					Comparator: spec.ComparatorBetween,
+					ParamIndex: 1,
+					Negated:    true,
+				},
+			}},
+		},
 		// FindByCity
 		spec.FindOperation{
 			Mode: spec.QueryModeMany,
@@ -156,6 +225,40 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			},
 		},
+		// FindByCityAndGenderAndAgeBetween
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Operator: spec.OperatorAnd,
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+						},
+						Comparator:  spec.ComparatorEqual,
+						ParamIndex:  1,
+						ParamFields: []string{"City"},
+						Optional:    true,
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						},
+						Comparator:  spec.ComparatorEqual,
+						ParamIndex:  1,
+						ParamFields: []string{"Gender"},
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+						},
+						Comparator:  spec.ComparatorBetween,
+						ParamIndex:  1,
+						ParamFields: []string{"AgeFrom", "AgeTo"},
+					},
+				},
+			},
+		},
 		// FindByCityIn
 		spec.FindOperation{
 			Mode: spec.QueryModeMany,
@@ -330,6 +433,59 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			},
 		},
+		// FindByConsentHistoryID
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+						testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByConsentHistoryIsNotNull
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+					},
+					Comparator: spec.ComparatorIsNotNull,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByCreatedAtAfter
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+					},
+					Comparator: spec.ComparatorAfter,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByCreatedAtBefore
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+					},
+					Comparator: spec.ComparatorBefore,
+					ParamIndex: 1,
+				},
+			}},
+		},
 		// FindByEnabledFalse
 		spec.FindOperation{
 			Mode: spec.QueryModeMany,
@@ -343,6 +499,20 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			}},
 		},
+		// FindByEnabledNotTrue
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+					},
+					Comparator: spec.ComparatorTrue,
+					ParamIndex: 1,
+					Negated:    true,
+				},
+			}},
+		},
 		// FindByEnabledTrue
 		spec.FindOperation{
 			Mode: spec.QueryModeMany,
@@ -356,6 +526,29 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			}},
 		},
+		// FindByGenderAndCityIn
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Operator: spec.OperatorAnd,
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 1,
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+						},
+						Comparator: spec.ComparatorIn,
+						ParamIndex: 2,
+					},
+				},
+			},
+		},
 		// FindByID
 		spec.FindOperation{
 			Mode: spec.QueryModeOne,
@@ -369,6 +562,19 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				},
 			}},
 		},
+		// FindByLastLoginWithinLast
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "LastLogin"),
+					},
+					Comparator: spec.ComparatorWithinLast,
+					ParamIndex: 1,
+				},
+			}},
+		},
 		// FindByNameFirst
 		spec.FindOperation{
 			Mode: spec.QueryModeMany,
@@ -376,125 +582,659 @@ func TestParseInterfaceMethod_Find(t *testing.T) {
 				{
 					FieldReference: spec.FieldReference{
 						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
-						testutils.FindStructFieldByName(testutils.TypeNameStruct, "First"),
+						testutils.FindStructFieldByName(testutils.TypeNameStruct, "First"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByNameLast
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
+						testutils.FindStructFieldByName(testutils.TypeNameStruct, "Last"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 1,
+				},
+			}},
+			ValueModel: true,
+		},
+		// FindByPhoneNumber
+		spec.FindOperation{
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "PhoneNumber"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByPhoneNumberAndCity
+		spec.FindOperation{
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{
+				Operator: spec.OperatorAnd,
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "PhoneNumber"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 1,
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 2,
+					},
+				},
+			},
+			ValueModel: true,
+		},
+		// FindByReferrerExists
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+					},
+					Comparator: spec.ComparatorExists,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByReferrerID
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByReferrerIsNull
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+					},
+					Comparator: spec.ComparatorIsNull,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByReferrerNotExists
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+					},
+					Comparator: spec.ComparatorNotExists,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByTextSearch
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					Comparator: spec.ComparatorTextSearch,
+					ParamIndex: 1,
+				},
+			}},
+		},
+		// FindByTextSearchAndCityOrderByScore
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Operator: spec.OperatorAnd,
+				Predicates: []spec.Predicate{
+					{
+						Comparator: spec.ComparatorTextSearch,
+						ParamIndex: 1,
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 2,
+					},
+				},
+			},
+			Sorts: []spec.Sort{
+				{
+					Ordering: spec.OrderingTextScore,
+				},
+			},
+		},
+		// FindByUpdatedAtGreaterThanCreatedAt
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "UpdatedAt"),
+					},
+					Comparator: spec.ComparatorGreaterThan,
+					ParamIndex: 1,
+					ComparedFieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+					},
+				},
+			}},
+		},
+		// FindTop5ByGenderOrderByAgeDesc
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 1,
+				},
+			}},
+			Sorts: []spec.Sort{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+					Ordering: spec.OrderingDescending,
+				},
+			},
+			Limit: 5,
+		},
+	}
+
+	for i := 0; i < repoIntf.NumMethods(); i++ {
+		method := repoIntf.Method(i)
+
+		t.Run(method.Name(), func(t *testing.T) {
+			actualSpec, err := spec.ParseInterfaceMethod(testutils.Pkg, testutils.TypeUserNamed, method)
+
+			if err != nil {
+				t.Errorf("Error = %s", err)
+			}
+			if method.Name() != actualSpec.Name {
+				t.Errorf("Expected = %+v\nReceived = %+v", method.Name(), actualSpec.Name)
+			}
+			if !types.Identical(method.Type(), actualSpec.Signature) {
+				t.Errorf("Expected = %+v\nReceived = %+v", method.Type(), actualSpec.Signature)
+			}
+			if !reflect.DeepEqual(expectedOperations[i], actualSpec.Operation) {
+				t.Errorf("Expected = %+v\nReceived = %+v", expectedOperations[i], actualSpec.Operation)
+			}
+		})
+	}
+}
+
+func TestParseInterfaceMethod_Update(t *testing.T) {
+	repoIntf := testutils.Pkg.Scope().Lookup("UserRepositoryUpdate").Type().Underlying().(*types.Interface)
+	position0 := 0
+
+	expectedOperations := []spec.Operation{
+		// UpdateAgeIncByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorInc,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateAgeMinByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorMin,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateAgeMulByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorMul,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateByID
+		spec.UpdateOperation{
+			Update: spec.UpdateModel{},
+			Mode:   spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateCityByGender
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+			},
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+			ReturnModified: true,
+		},
+		// UpdateConsentHistoryDollarValueByIDAndConsentHistoryID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+						testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+					},
+					ParamIndex:      1,
+					Operator:        spec.UpdateOperatorSet,
+					Positional:      spec.PositionalFirst,
+					PositionalDepth: 1,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{
+				Operator: spec.OperatorAnd,
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 2,
+					},
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+							testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "ID"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 3,
+					},
+				},
+			},
+		},
+		// UpdateConsentHistoryElemValueByConsentHistoryValue
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+						testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+					},
+					ParamIndex:      1,
+					Operator:        spec.UpdateOperatorSet,
+					Positional:      spec.PositionalFiltered,
+					PositionalDepth: 1,
+				},
+			},
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+						testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateConsentHistoryPushByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorPush,
+				},
+			},
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateEnabledAndConsentHistoryPushByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+					},
+					ParamIndex: 2,
+					Operator:   spec.UpdateOperatorPush,
+				},
+			},
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 3,
+				},
+			}},
+		},
+		// UpdateGenderAndCityByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+					},
+					ParamIndex: 2,
+					Operator:   spec.UpdateOperatorSet,
+				},
+			},
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 3,
+				},
+			}},
+		},
+		// UpdateGenderByAge
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+			},
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateGenderByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateLastLoginMaxByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "LastLogin"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorMax,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdateNameFirstByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
+						testutils.FindStructFieldByName(testutils.TypeNameStruct, "First"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+			},
+			Mode: spec.QueryModeOne,
+			Query: spec.QuerySpec{Predicates: []spec.Predicate{
+				{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
+					},
+					Comparator: spec.ComparatorEqual,
+					ParamIndex: 2,
+				},
+			}},
+		},
+		// UpdatePatchByID
+		spec.UpdateOperation{
+			Update: spec.UpdatePatch{
+				spec.UpdatePatchField{
+					Name: "City",
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+					},
+				},
+				spec.UpdatePatchField{
+					Name: "Age",
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+					},
+				},
+				spec.UpdatePatchField{
+					Name: "Name",
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
 					},
-					Comparator: spec.ComparatorEqual,
-					ParamIndex: 1,
 				},
-			}},
-		},
-		// FindByPhoneNumber
-		spec.FindOperation{
+			},
 			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "PhoneNumber"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 1,
+					ParamIndex: 2,
 				},
 			}},
 		},
-		// FindByReferrerExists
-		spec.FindOperation{
-			Mode: spec.QueryModeMany,
-			Query: spec.QuerySpec{Predicates: []spec.Predicate{
-				{
+		// UpdateReferrerUnsetAndCityByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
 					FieldReference: spec.FieldReference{
 						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
 					},
-					Comparator: spec.ComparatorExists,
 					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorUnset,
 				},
-			}},
-		},
-		// FindByReferrerID
-		spec.FindOperation{
-			Mode: spec.QueryModeMany,
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorSet,
+				},
+			},
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
 						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 1,
+					ParamIndex: 2,
 				},
 			}},
 		},
-		// FindByReferrerNotExists
-		spec.FindOperation{
+		// UpdateRenamePhoneNumberToCityAll
+		spec.UpdateOperation{
+			Update: spec.UpdateRename{
+				From: spec.FieldReference{
+					testutils.FindStructFieldByName(testutils.TypeUserStruct, "PhoneNumber"),
+				},
+				To: spec.FieldReference{
+					testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+				},
+			},
 			Mode: spec.QueryModeMany,
-			Query: spec.QuerySpec{Predicates: []spec.Predicate{
-				{
+		},
+		// UpdateTagsAddToSetByID
+		spec.UpdateOperation{
+			Update: spec.UpdateFields{
+				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Referrer"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
 					},
-					Comparator: spec.ComparatorNotExists,
 					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorAddToSet,
 				},
-			}},
-		},
-		// FindTop5ByGenderOrderByAgeDesc
-		spec.FindOperation{
-			Mode: spec.QueryModeMany,
+			},
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 1,
+					ParamIndex: 2,
 				},
 			}},
-			Sorts: []spec.Sort{
-				{
-					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
-					},
-					Ordering: spec.OrderingDescending,
-				},
-			},
-			Limit: 5,
 		},
-	}
-
-	for i := 0; i < repoIntf.NumMethods(); i++ {
-		method := repoIntf.Method(i)
-
-		t.Run(method.Name(), func(t *testing.T) {
-			actualSpec, err := spec.ParseInterfaceMethod(testutils.Pkg, testutils.TypeUserNamed, method)
-
-			if err != nil {
-				t.Errorf("Error = %s", err)
-			}
-			if method.Name() != actualSpec.Name {
-				t.Errorf("Expected = %+v\nReceived = %+v", method.Name(), actualSpec.Name)
-			}
-			if !types.Identical(method.Type(), actualSpec.Signature) {
-				t.Errorf("Expected = %+v\nReceived = %+v", method.Type(), actualSpec.Signature)
-			}
-			if !reflect.DeepEqual(expectedOperations[i], actualSpec.Operation) {
-				t.Errorf("Expected = %+v\nReceived = %+v", expectedOperations[i], actualSpec.Operation)
-			}
-		})
-	}
-}
-
-func TestParseInterfaceMethod_Update(t *testing.T) {
-	repoIntf := testutils.Pkg.Scope().Lookup("UserRepositoryUpdate").Type().Underlying().(*types.Interface)
-
-	expectedOperations := []spec.Operation{
-		// UpdateAgeIncByID
+		// UpdateTagsPullAllByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
 					},
 					ParamIndex: 1,
-					Operator:   spec.UpdateOperatorInc,
+					Operator:   spec.UpdateOperatorPullAll,
 				},
 			},
 			Mode: spec.QueryModeOne,
@@ -508,10 +1248,18 @@ func TestParseInterfaceMethod_Update(t *testing.T) {
 				},
 			}},
 		},
-		// UpdateByID
+		// UpdateTagsPullByID
 		spec.UpdateOperation{
-			Update: spec.UpdateModel{},
-			Mode:   spec.QueryModeOne,
+			Update: spec.UpdateFields{
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
+					},
+					ParamIndex: 1,
+					Operator:   spec.UpdateOperatorPull,
+				},
+			},
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
@@ -522,18 +1270,18 @@ func TestParseInterfaceMethod_Update(t *testing.T) {
 				},
 			}},
 		},
-		// UpdateConsentHistoryPushByID
+		// UpdateTagsPushEachByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
 					},
 					ParamIndex: 1,
-					Operator:   spec.UpdateOperatorPush,
+					Operator:   spec.UpdateOperatorPushEach,
 				},
 			},
-			Mode: spec.QueryModeMany,
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
@@ -544,96 +1292,96 @@ func TestParseInterfaceMethod_Update(t *testing.T) {
 				},
 			}},
 		},
-		// UpdateEnabledAndConsentHistoryPushByID
+		// UpdateTagsPushEachPosition0ByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
 					},
 					ParamIndex: 1,
-					Operator:   spec.UpdateOperatorSet,
-				},
-				spec.UpdateField{
-					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+					Operator:   spec.UpdateOperatorPushEach,
+					PushEach: spec.PushEachModifiers{
+						Position: &position0,
 					},
-					ParamIndex: 2,
-					Operator:   spec.UpdateOperatorPush,
 				},
 			},
-			Mode: spec.QueryModeMany,
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
 						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 3,
+					ParamIndex: 2,
 				},
 			}},
 		},
-		// UpdateGenderAndCityByID
+		// UpdateTagsPushEachSlice50SortDescByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Tags"),
 					},
 					ParamIndex: 1,
-					Operator:   spec.UpdateOperatorSet,
-				},
-				spec.UpdateField{
-					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+					Operator:   spec.UpdateOperatorPushEach,
+					PushEach: spec.PushEachModifiers{
+						Slice: 50,
+						Sort:  spec.OrderingDescending,
 					},
-					ParamIndex: 2,
-					Operator:   spec.UpdateOperatorSet,
 				},
 			},
-			Mode: spec.QueryModeMany,
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
 						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 3,
+					ParamIndex: 2,
 				},
 			}},
 		},
-		// UpdateGenderByAge
+		// UpdateUpdatedAtCurrentDateByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "UpdatedAt"),
 					},
 					ParamIndex: 1,
-					Operator:   spec.UpdateOperatorSet,
+					Operator:   spec.UpdateOperatorCurrentDate,
 				},
 			},
-			Mode: spec.QueryModeMany,
+			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
 				{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 2,
+					ParamIndex: 1,
 				},
 			}},
 		},
-		// UpdateGenderByID
+		// UpsertCityAndCreatedAtSetOnInsertByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
 					},
 					ParamIndex: 1,
 					Operator:   spec.UpdateOperatorSet,
 				},
+				spec.UpdateField{
+					FieldReference: spec.FieldReference{
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt"),
+					},
+					ParamIndex: 2,
+					Operator:   spec.UpdateOperatorSetOnInsert,
+				},
 			},
 			Mode: spec.QueryModeOne,
 			Query: spec.QuerySpec{Predicates: []spec.Predicate{
@@ -642,17 +1390,17 @@ func TestParseInterfaceMethod_Update(t *testing.T) {
 						testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID"),
 					},
 					Comparator: spec.ComparatorEqual,
-					ParamIndex: 2,
+					ParamIndex: 3,
 				},
 			}},
+			Upsert: true,
 		},
-		// UpdateNameFirstByID
+		// UpsertCityByID
 		spec.UpdateOperation{
 			Update: spec.UpdateFields{
 				spec.UpdateField{
 					FieldReference: spec.FieldReference{
-						testutils.FindStructFieldByName(testutils.TypeUserStruct, "Name"),
-						testutils.FindStructFieldByName(testutils.TypeNameStruct, "First"),
+						testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
 					},
 					ParamIndex: 1,
 					Operator:   spec.UpdateOperatorSet,
@@ -668,6 +1416,8 @@ func TestParseInterfaceMethod_Update(t *testing.T) {
 					ParamIndex: 2,
 				},
 			}},
+			Upsert:         true,
+			UpsertedIDType: testutils.FindStructFieldByName(testutils.TypeUserStruct, "ID").Var.Type(),
 		},
 	}
 
@@ -976,6 +1726,73 @@ func TestParseInterfaceMethod_Count(t *testing.T) {
 	}
 }
 
+func TestParseInterfaceMethod_FieldNameEndingWithKeyword(t *testing.T) {
+	repoIntf := testutils.Pkg.Scope().Lookup("SessionRepositoryFind").Type().Underlying().(*types.Interface)
+
+	expectedOperations := []spec.Operation{
+		// FindByNotBefore
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeSessionStruct, "NotBefore"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 1,
+					},
+				},
+			},
+		},
+		// FindByNotBeforeAfter
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeSessionStruct, "NotBefore"),
+						},
+						Comparator: spec.ComparatorAfter,
+						ParamIndex: 1,
+					},
+				},
+			},
+		},
+		// FindByRetryAfter
+		spec.FindOperation{
+			Mode: spec.QueryModeMany,
+			Query: spec.QuerySpec{
+				Predicates: []spec.Predicate{
+					{
+						FieldReference: spec.FieldReference{
+							testutils.FindStructFieldByName(testutils.TypeSessionStruct, "RetryAfter"),
+						},
+						Comparator: spec.ComparatorEqual,
+						ParamIndex: 1,
+					},
+				},
+			},
+		},
+	}
+
+	for i := 0; i < repoIntf.NumMethods(); i++ {
+		method := repoIntf.Method(i)
+
+		t.Run(method.Name(), func(t *testing.T) {
+			actualSpec, err := spec.ParseInterfaceMethod(testutils.Pkg, testutils.TypeSessionNamed, method)
+
+			if err != nil {
+				t.Fatalf("Error = %s", err)
+			}
+			if !reflect.DeepEqual(expectedOperations[i], actualSpec.Operation) {
+				t.Errorf("Expected = %+v\nReceived = %+v", expectedOperations[i], actualSpec.Operation)
+			}
+		})
+	}
+}
+
 func TestParseInterfaceMethod_InvalidOperation(t *testing.T) {
 	repoIntf := testutils.Pkg.Scope().Lookup("UserRepositoryInvalidOperation").Type().Underlying().(*types.Interface)
 	method := repoIntf.Method(0)
@@ -995,7 +1812,7 @@ func TestParseInterfaceMethod_Insert_Invalid(t *testing.T) {
 		// Insert1
 		spec.NewOperationReturnCountUnmatchedError(2),
 		// Insert2
-		spec.NewUnsupportedReturnError(types.NewPointer(testutils.TypeUserNamed), 0),
+		spec.NewUnsupportedReturnError(types.NewPointer(testutils.Pkg.Scope().Lookup("Name").Type()), 0),
 		// Insert3
 		spec.NewUnsupportedReturnError(repoIntf.Method(2).Type().(*types.Signature).Results().At(0).Type(), 0),
 		// Insert4
@@ -1006,6 +1823,10 @@ func TestParseInterfaceMethod_Insert_Invalid(t *testing.T) {
 		spec.ErrInvalidParam,
 		// Insert7
 		spec.ErrInvalidParam,
+		// Insert8
+		spec.NewUnsupportedReturnError(code.TypeString, 0),
+		// Insert9
+		spec.ErrInvalidParam,
 	}
 
 	for i := 0; i < repoIntf.NumMethods(); i++ {
@@ -1037,16 +1858,39 @@ func TestParseInterfaceMethod_Find_Invalid(t *testing.T) {
 		spec.NewInvalidSortError([]string{"Order", "By", "And", "Age"}),
 		// FindAllOrderByCountry
 		spec.NewStructFieldNotFoundError([]string{"Country"}),
+		// FindAllOrderByScore
+		spec.NewStructFieldNotFoundError([]string{"Score"}),
 		// FindByAge
 		spec.ErrContextParamRequired,
+		// FindByAgeBetween
+		spec.ErrMixedOptionalArguments,
+		// FindByAgeNotBetween
+		spec.ErrMixedOptionalArguments,
 		// FindByAndGender
 		spec.NewInvalidQueryError([]string{"And", "Gender"}),
 		// FindByCity
 		spec.ErrInvalidParam,
+		// FindByCityAndEnabled
+		spec.NewQueryStructFieldNotFoundError("Enabled"),
+		// FindByCityBefore
+		spec.NewIncompatibleComparatorError(spec.ComparatorBefore,
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City")),
 		// FindByCityIn
 		spec.NewArgumentTypeNotMatchedError("City", types.NewSlice(code.TypeString), code.TypeString),
+		// FindByCityIsNull
+		spec.NewIncompatibleComparatorError(spec.ComparatorIsNull,
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City")),
+		// FindByCityOrGender
+		spec.NewArgumentTypeNotMatchedError("City", code.TypeString, code.TypeInt),
 		// FindByCountry
 		spec.NewStructFieldNotFoundError([]string{"Country"}),
+		// FindByCreatedAtLessThanAge
+		spec.NewIncomparableFieldsError(
+			spec.FieldReference{testutils.FindStructFieldByName(testutils.TypeUserStruct, "CreatedAt")},
+			spec.FieldReference{testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age")},
+		),
+		// FindByCreatedAtWithinLast
+		spec.NewArgumentTypeNotMatchedError("CreatedAt", code.TypeDuration, code.TypeInt),
 		// FindByGender
 		spec.NewArgumentTypeNotMatchedError("Gender", testutils.TypeGenderNamed, code.TypeString),
 		// FindByGenderAnd
@@ -1062,9 +1906,15 @@ func TestParseInterfaceMethod_Find_Invalid(t *testing.T) {
 		spec.NewIncompatibleComparatorError(spec.ComparatorTrue,
 			testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender")),
 		// FindByID
-		spec.NewUnsupportedReturnError(testutils.TypeUserNamed, 0),
+		spec.NewUnsupportedReturnError(testutils.Pkg.Scope().Lookup("Name").Type(), 0),
+		// FindByLastLoginAfter
+		spec.NewArgumentTypeNotMatchedError("LastLogin", testutils.TypeDateTimeNamed, testutils.TypeTimeNamed),
 		// FindByNameMiddle
 		spec.NewStructFieldNotFoundError([]string{"Name", "Middle"}),
+		// FindByTags
+		spec.ErrInvalidVariadicParam,
+		// FindByTextSearch
+		spec.NewArgumentTypeNotMatchedError("TextSearch", code.TypeString, code.TypeInt),
 		// FindTop
 		spec.ErrLimitAmountRequired,
 		// FindTop0All
@@ -1106,29 +1956,109 @@ func TestParseInterfaceMethod_Update_Invalid(t *testing.T) {
 		spec.ErrInvalidUpdateFields,
 		// UpdateCity
 		spec.ErrQueryRequired,
+		// UpdateCityByAge
+		spec.NewUnsupportedReturnError(code.TypeBool, 1),
 		// UpdateCityByID
 		spec.NewUnsupportedReturnError(code.TypeFloat64, 0),
+		// UpdateCityCurrentDateByID
+		spec.NewIncompatibleUpdateOperatorError(spec.UpdateOperatorCurrentDate, spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+		}),
 		// UpdateCityIncByID
 		spec.NewIncompatibleUpdateOperatorError(spec.UpdateOperatorInc, spec.FieldReference{
 			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
 		}),
+		// UpdateCityMulByID
+		spec.NewIncompatibleUpdateOperatorError(spec.UpdateOperatorMul, spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+		}),
+		// UpdateCityPullByID
+		spec.NewIncompatibleUpdateOperatorError(spec.UpdateOperatorPull, spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+		}),
+		// UpdateCitySetOnInsertByID
+		spec.ErrSetOnInsertWithoutUpsert,
+		// UpdateConsentHistoryDollarValueByID
+		spec.NewPositionalQueryRequiredError(spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+		}),
+		// UpdateConsentHistoryDollarValueByIDAndConsentHistoryID
+		spec.NewOptionalPositionalQueryError(spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+		}),
+		// UpdateConsentHistoryElemValueAndReferrerConsentHistoryElemValueByConsentHistoryValueAndReferrerConsentHistoryValue
+		spec.ErrMultipleFilteredArrays,
+		// UpdateConsentHistoryElemValueByConsentHistoryIDGreaterThanID
+		spec.NewFieldComparisonArrayFilterError(spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+		}),
 		// UpdateConsentHistoryPushByID
 		spec.NewArgumentTypeNotMatchedError("ConsentHistory",
 			testutils.TypeConsentHistoryNamed, types.NewSlice(testutils.TypeConsentHistoryNamed)),
+		// UpdateConsentHistoryValueByID
+		spec.NewPositionalRequiredError(spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+			testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+		}),
 		// UpdateCountryByGender
 		spec.NewStructFieldNotFoundError([]string{"Country"}),
 		// UpdateEnabledAll
 		spec.ErrInvalidUpdateFields,
+		// UpdateEnabledByAge
+		spec.NewOptionalPredicateNotSupportedError("Update", spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+		}),
 		// UpdateEnabledByCity
 		spec.NewArgumentTypeNotMatchedError("City", code.TypeString, code.TypeInt),
+		// UpdateEnabledByCityAndGender
+		spec.NewOptionalPredicateNotSupportedError("Update", spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+		}),
 		// UpdateEnabledByGender
 		spec.NewArgumentTypeNotMatchedError("Enabled", code.TypeBool, code.TypeInt),
 		// UpdateEnabledByID
 		spec.NewUnsupportedReturnError(code.TypeBool, 1),
+		// UpdateEnabledMaxByID
+		spec.NewIncompatibleUpdateOperatorError(spec.UpdateOperatorMax, spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "Enabled"),
+		}),
 		// UpdateGenderPushByID
 		spec.NewIncompatibleUpdateOperatorError(spec.UpdateOperatorPush, spec.FieldReference{
 			testutils.FindStructFieldByName(testutils.TypeUserStruct, "Gender"),
 		}),
+		// UpdatePatchByAge
+		spec.NewPatchFieldNotPointerError("City"),
+		// UpdatePatchByCity
+		spec.NewArgumentTypeNotMatchedError("Age", types.NewPointer(code.TypeInt), types.NewPointer(code.TypeString)),
+		// UpdatePatchByEnabled
+		spec.ErrPointerPatchParam,
+		// UpdatePatchByGender
+		spec.NewStructFieldNotFoundError([]string{"Country"}),
+		// UpdateRenameAgeToCityAll
+		spec.NewIncompatibleRenameError(spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+		}, spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+		}),
+		// UpdateRenameCityToCityAll
+		spec.ErrInvalidUpdateFields,
+		// UpdateRenameConsentHistoryValueToEnabledAll
+		spec.NewPositionalRequiredError(spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "ConsentHistory"),
+			testutils.FindStructFieldByName(testutils.TypeConsentHistoryStruct, "Value"),
+		}),
+		// UpdateRenameCountryToCityAll
+		spec.NewStructFieldNotFoundError([]string{"Country"}),
+		// UpdateTagsPullAllByID
+		spec.NewArgumentTypeNotMatchedError("Tags", types.NewSlice(code.TypeString), code.TypeString),
+		// UpdateTagsPushEachLimit5ByID
+		spec.ErrInvalidUpdateFields,
+		// UpdateTagsPushEachPositionByID
+		spec.ErrInvalidUpdateFields,
+		// UpdateTagsPushEachSlice0ByID
+		spec.ErrPushEachSliceNonPositive,
+		// UpsertCityByID
+		spec.NewUnsupportedReturnError(code.TypeString, 1),
 	}
 
 	for i := 0; i < repoIntf.NumMethods(); i++ {
@@ -1154,10 +2084,18 @@ func TestParseInterfaceMethod_Delete_Invalid(t *testing.T) {
 		spec.NewOperationReturnCountUnmatchedError(2),
 		// DeleteByAge
 		spec.NewUnsupportedReturnError(code.TypeFloat64, 0),
+		// DeleteByAgeGreaterThan
+		spec.NewOptionalPredicateNotSupportedError("Delete", spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "Age"),
+		}),
 		// DeleteByAndGender
 		spec.NewInvalidQueryError([]string{"And", "Gender"}),
 		// DeleteByCity
 		spec.NewUnsupportedReturnError(code.TypeBool, 1),
+		// DeleteByCityAndGender
+		spec.NewOptionalPredicateNotSupportedError("Delete", spec.FieldReference{
+			testutils.FindStructFieldByName(testutils.TypeUserStruct, "City"),
+		}),
 		// DeleteByCityIn
 		spec.NewArgumentTypeNotMatchedError("City", types.NewSlice(code.TypeString), code.TypeString),
 		// DeleteByCountry
@@ -1,6 +1,11 @@
 package spec
 
-import "go/types"
+import (
+	"go/types"
+	"strings"
+
+	"github.com/sunboyy/repogen/internal/code"
+)
 
 // QuerySpec is a set of conditions of querying the database
 type QuerySpec struct {
@@ -12,11 +17,66 @@ type QuerySpec struct {
 func (q QuerySpec) NumberOfArguments() int {
 	var totalArgs int
 	for _, predicate := range q.Predicates {
-		totalArgs += predicate.Comparator.NumberOfArguments()
+		totalArgs += predicate.NumberOfArguments()
 	}
 	return totalArgs
 }
 
+// HasTextSearch returns true if any of the predicates is a text search
+func (q QuerySpec) HasTextSearch() bool {
+	for _, predicate := range q.Predicates {
+		if predicate.Comparator == ComparatorTextSearch {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPredicateOn returns true if any of the predicates queries the given field
+// or its nested fields.
+func (q QuerySpec) hasPredicateOn(fieldReference FieldReference) bool {
+	for _, predicate := range q.Predicates {
+		if predicate.FieldReference.HasPrefix(fieldReference) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFieldComparisonOn returns true if any predicate compares the given field
+// or its descendants with another field of the document.
+func (q QuerySpec) hasFieldComparisonOn(fieldReference FieldReference) bool {
+	for _, predicate := range q.Predicates {
+		if predicate.ComparedFieldReference != nil && predicate.FieldReference.HasPrefix(fieldReference) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRequiredPredicateOn returns true if any non-optional predicate queries
+// the given field or its descendants.
+func (q QuerySpec) hasRequiredPredicateOn(fieldReference FieldReference) bool {
+	for _, predicate := range q.Predicates {
+		if !predicate.Optional && predicate.FieldReference.HasPrefix(fieldReference) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRequiredPredicates returns an error if any predicate is optional.
+// The operations that write to the database do not support optional predicates
+// since a nil argument would widen the query to match every document.
+func (q QuerySpec) validateRequiredPredicates(operationName string) error {
+	for _, predicate := range q.Predicates {
+		if predicate.Optional {
+			return NewOptionalPredicateNotSupportedError(operationName, predicate.FieldReference)
+		}
+	}
+	return nil
+}
+
 // Operator is a boolean operator for merging conditions
 type Operator string
 
@@ -44,6 +104,12 @@ const (
 	ComparatorFalse            Comparator = "EQUAL_FALSE"
 	ComparatorExists           Comparator = "EXISTS"
 	ComparatorNotExists        Comparator = "NOT_EXISTS"
+	ComparatorTextSearch       Comparator = "TEXT_SEARCH"
+	ComparatorIsNull           Comparator = "IS_NULL"
+	ComparatorIsNotNull        Comparator = "IS_NOT_NULL"
+	ComparatorBefore           Comparator = "BEFORE"
+	ComparatorAfter            Comparator = "AFTER"
+	ComparatorWithinLast       Comparator = "WITHIN_LAST"
 )
 
 // ArgumentTypeFromFieldType returns a type of required argument from the given
@@ -52,6 +118,10 @@ func (c Comparator) ArgumentTypeFromFieldType(t types.Type) types.Type {
 	switch c {
 	case ComparatorIn, ComparatorNotIn:
 		return types.NewSlice(t)
+	case ComparatorTextSearch:
+		return code.TypeString
+	case ComparatorWithinLast:
+		return code.TypeDuration
 	default:
 		return t
 	}
@@ -63,18 +133,56 @@ func (c Comparator) NumberOfArguments() int {
 	switch c {
 	case ComparatorBetween:
 		return 2
-	case ComparatorTrue, ComparatorFalse, ComparatorExists, ComparatorNotExists:
+	case ComparatorTrue, ComparatorFalse, ComparatorExists, ComparatorNotExists,
+		ComparatorIsNull, ComparatorIsNotNull:
 		return 0
 	default:
 		return 1
 	}
 }
 
-// Predicate is a criteria for querying a field
+// Predicate is a criteria for querying a field. The text search predicate is
+// not tied to any field so its FieldReference is left empty.
 type Predicate struct {
 	FieldReference FieldReference
 	Comparator     Comparator
 	ParamIndex     int
+	// Negated inverts the result of the comparison (e.g. NotBetween)
+	Negated bool
+	// ComparedFieldReference is a field of the same document to compare with
+	// instead of a method parameter (e.g. UpdatedAtGreaterThanCreatedAt).
+	ComparedFieldReference FieldReference
+	// ParamFields is the names of the fields of the query struct parameter at
+	// ParamIndex that hold the arguments. It is empty if the arguments are
+	// given as positional parameters.
+	ParamFields []string
+	// Optional indicates that the arguments are pointers and the predicate is
+	// omitted from the query when any of them is nil.
+	Optional bool
+}
+
+// NumberOfArguments returns the number of arguments required to perform the
+// predicate. Comparing with another field does not require any argument.
+func (p Predicate) NumberOfArguments() int {
+	if p.ComparedFieldReference != nil {
+		return 0
+	}
+	return p.Comparator.NumberOfArguments()
+}
+
+// queryStructFieldNames returns the names of the query struct fields that hold
+// the arguments of the predicate, e.g. City for CityIn and AgeFrom and AgeTo
+// for AgeBetween.
+func (p Predicate) queryStructFieldNames() []string {
+	if p.Comparator == ComparatorTextSearch {
+		return []string{"TextSearch"}
+	}
+
+	name := strings.ReplaceAll(p.FieldReference.ReferencingCode(), ".", "")
+	if p.Comparator == ComparatorBetween {
+		return []string{name + "From", name + "To"}
+	}
+	return []string{name}
 }
 
 type queryParser struct {
@@ -121,7 +229,7 @@ func (p queryParser) parseQueryBy(rawTokens []string, paramIndex int) (QuerySpec
 			return QuerySpec{}, err
 		}
 		querySpec.Predicates = append(querySpec.Predicates, predicate)
-		paramIndex += predicate.Comparator.NumberOfArguments()
+		paramIndex += predicate.NumberOfArguments()
 	}
 
 	return querySpec, nil
@@ -160,6 +268,21 @@ func (p queryParser) splitPredicateTokens(tokens []string) (Operator, [][]string
 func (p queryParser) parsePredicate(t []string, paramIndex int) (Predicate,
 	error) {
 
+	if len(t) == 2 && t[0] == "Text" && t[1] == "Search" {
+		return Predicate{
+			Comparator: ComparatorTextSearch,
+			ParamIndex: paramIndex,
+		}, nil
+	}
+
+	predicate, ok, err := p.parseFieldComparisonPredicate(t, paramIndex)
+	if err != nil {
+		return Predicate{}, err
+	}
+	if ok {
+		return predicate, nil
+	}
+
 	switch {
 	case endsWith(t, "Not"):
 		return p.createPredicate(t[:len(t)-1], ComparatorNot, paramIndex)
@@ -182,6 +305,21 @@ func (p queryParser) parsePredicate(t []string, paramIndex int) (Predicate,
 	case endsWith(t, "Not", "Exists"):
 		return p.createPredicate(t[:len(t)-2], ComparatorNotExists, paramIndex)
 
+	case endsWith(t, "Is", "Not", "Null"):
+		return p.createPredicate(t[:len(t)-3], ComparatorIsNotNull, paramIndex)
+
+	case endsWith(t, "Is", "Null"):
+		return p.createPredicate(t[:len(t)-2], ComparatorIsNull, paramIndex)
+
+	case endsWith(t, "Before"):
+		return p.createTimePredicate(t, 1, ComparatorBefore, paramIndex)
+
+	case endsWith(t, "After"):
+		return p.createTimePredicate(t, 1, ComparatorAfter, paramIndex)
+
+	case endsWith(t, "Within", "Last"):
+		return p.createTimePredicate(t, 2, ComparatorWithinLast, paramIndex)
+
 	case endsWith(t, "In"):
 		return p.createPredicate(t[:len(t)-1], ComparatorIn, paramIndex)
 
@@ -201,6 +339,63 @@ func (p queryParser) parsePredicate(t []string, paramIndex int) (Predicate,
 	return p.createPredicate(t, ComparatorEqual, paramIndex)
 }
 
+// fieldComparators lists comparators that can compare two fields of the same
+// document. Longer keywords are placed first so that they take precedence.
+var fieldComparators = []struct {
+	Tokens     []string
+	Comparator Comparator
+}{
+	{Tokens: []string{"Less", "Than", "Equal"}, Comparator: ComparatorLessThanEqual},
+	{Tokens: []string{"Less", "Than"}, Comparator: ComparatorLessThan},
+	{Tokens: []string{"Greater", "Than", "Equal"}, Comparator: ComparatorGreaterThanEqual},
+	{Tokens: []string{"Greater", "Than"}, Comparator: ComparatorGreaterThan},
+}
+
+func (p queryParser) parseFieldComparisonPredicate(t []string, paramIndex int) (Predicate, bool, error) {
+	for i := 1; i < len(t); i++ {
+		for _, fieldComparator := range fieldComparators {
+			rightIndex := i + len(fieldComparator.Tokens)
+			if rightIndex >= len(t) || !endsWith(t[:rightIndex], fieldComparator.Tokens...) {
+				continue
+			}
+
+			leftFields, negated, ok := p.resolveNegatableField(t[:i], fieldComparator.Comparator)
+			if !ok {
+				continue
+			}
+			rightFields, ok := resolveStructField(p.UnderlyingStruct, t[rightIndex:])
+			if !ok {
+				continue
+			}
+
+			if !isComparableFieldTypes(leftFields.ReferencedField().Var.Type(),
+				rightFields.ReferencedField().Var.Type()) {
+				return Predicate{}, false, NewIncomparableFieldsError(leftFields, rightFields)
+			}
+
+			return Predicate{
+				FieldReference:         leftFields,
+				Comparator:             fieldComparator.Comparator,
+				ParamIndex:             paramIndex,
+				Negated:                negated,
+				ComparedFieldReference: rightFields,
+			}, true, nil
+		}
+	}
+
+	return Predicate{}, false, nil
+}
+
+func isComparableFieldTypes(t1 types.Type, t2 types.Type) bool {
+	if types.Identical(t1, t2) {
+		return true
+	}
+
+	basicType1, ok1 := t1.Underlying().(*types.Basic)
+	basicType2, ok2 := t2.Underlying().(*types.Basic)
+	return ok1 && ok2 && basicType1.Info()&types.IsNumeric != 0 && basicType2.Info()&types.IsNumeric != 0
+}
+
 func endsWith(t []string, suffix ...string) bool {
 	if len(t) < len(suffix) {
 		return false
@@ -217,7 +412,7 @@ func endsWith(t []string, suffix ...string) bool {
 func (p queryParser) createPredicate(t []string, comparator Comparator,
 	paramIndex int) (Predicate, error) {
 
-	fields, ok := resolveStructField(p.UnderlyingStruct, t)
+	fields, negated, ok := p.resolveNegatableField(t, comparator)
 	if !ok {
 		return Predicate{}, NewStructFieldNotFoundError(t)
 	}
@@ -226,5 +421,36 @@ func (p queryParser) createPredicate(t []string, comparator Comparator,
 		FieldReference: fields,
 		Comparator:     comparator,
 		ParamIndex:     paramIndex,
+		Negated:        negated,
 	}, nil
 }
+
+// createTimePredicate creates the predicate of a time comparator whose keyword
+// is the last keywordLength tokens. The keyword can also be the end of a field
+// name (e.g. RetryAfter or NotBefore), in which case the whole tokens are
+// compared with equality if they do not resolve without the keyword.
+func (p queryParser) createTimePredicate(t []string, keywordLength int, comparator Comparator,
+	paramIndex int) (Predicate, error) {
+
+	predicate, err := p.createPredicate(t[:len(t)-keywordLength], comparator, paramIndex)
+	if err != nil {
+		if _, ok := resolveStructField(p.UnderlyingStruct, t); ok {
+			return p.createPredicate(t, ComparatorEqual, paramIndex)
+		}
+	}
+	return predicate, err
+}
+
+// resolveNegatableField resolves the struct field from the tokens. If the
+// tokens end with Not, the comparator is considered negated.
+func (p queryParser) resolveNegatableField(t []string, comparator Comparator) (FieldReference, bool, bool) {
+	if comparator != ComparatorNot && len(t) > 1 && t[len(t)-1] == "Not" {
+		fields, ok := resolveStructField(p.UnderlyingStruct, t[:len(t)-1])
+		if ok {
+			return fields, true, true
+		}
+	}
+
+	fields, ok := resolveStructField(p.UnderlyingStruct, t)
+	return fields, false, ok
+}
@@ -1,12 +1,26 @@
 package spec
 
-import "go/types"
+import (
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"github.com/sunboyy/repogen/internal/code"
+)
 
 // UpdateOperation is a method specification for update operations
 type UpdateOperation struct {
 	Update Update
 	Mode   QueryMode
 	Query  QuerySpec
+	// Upsert inserts a new document when no document matches the query
+	Upsert bool
+	// ReturnModified returns whether the documents are modified (or the number
+	// of modified documents) along with the matching result.
+	ReturnModified bool
+	// UpsertedIDType is the type of the ID of the upserted document that is
+	// returned before the error. It is nil if the upserted ID is not returned.
+	UpsertedIDType types.Type
 }
 
 // Name returns "Update" operation name
@@ -34,6 +48,44 @@ func (u UpdateModel) NumberOfArguments() int {
 	return 1
 }
 
+// UpdatePatch is a type of update operation that update the model fields
+// whose corresponding pointer fields of the patch struct are not nil
+type UpdatePatch []UpdatePatchField
+
+// Name returns UpdatePatch name 'Patch'
+func (u UpdatePatch) Name() string {
+	return "Patch"
+}
+
+// NumberOfArguments returns 1
+func (u UpdatePatch) NumberOfArguments() int {
+	return 1
+}
+
+// UpdatePatchField stores mapping between field name in the patch struct and
+// the field in the model.
+type UpdatePatchField struct {
+	Name           string
+	FieldReference FieldReference
+}
+
+// UpdateRename is a type of update operation that renames a field in the
+// documents
+type UpdateRename struct {
+	From FieldReference
+	To   FieldReference
+}
+
+// Name returns UpdateRename name 'Rename'
+func (u UpdateRename) Name() string {
+	return "Rename"
+}
+
+// NumberOfArguments returns 0
+func (u UpdateRename) NumberOfArguments() int {
+	return 0
+}
+
 // UpdateFields is a type of update operation that update specific fields
 type UpdateFields []UpdateField
 
@@ -42,9 +94,13 @@ func (u UpdateFields) Name() string {
 	return "Fields"
 }
 
-// NumberOfArguments returns number of update fields
+// NumberOfArguments returns number of arguments required by all update fields
 func (u UpdateFields) NumberOfArguments() int {
-	return len(u)
+	var totalArgs int
+	for _, field := range u {
+		totalArgs += field.Operator.NumberOfArguments()
+	}
+	return totalArgs
 }
 
 // UpdateField stores mapping between field name in the model and the parameter
@@ -53,6 +109,62 @@ type UpdateField struct {
 	FieldReference FieldReference
 	ParamIndex     int
 	Operator       UpdateOperator
+	PushEach       PushEachModifiers
+	// Positional is the positional operator applied to the array field at
+	// FieldReference[PositionalDepth-1] (e.g. ConsentHistoryDollarValue). Empty
+	// means that no positional operator is applied.
+	Positional      Positional
+	PositionalDepth int
+}
+
+// referencedType returns the type of the value being updated. When the
+// positional operator is applied to the referenced array field itself, it is
+// the array element type.
+func (f UpdateField) referencedType() types.Type {
+	fieldType := f.FieldReference.ReferencedField().Var.Type()
+	if f.Positional != "" && f.PositionalDepth == len(f.FieldReference) {
+		return fieldType.(*types.Slice).Elem()
+	}
+	return fieldType
+}
+
+// crossesArray returns true if the updated field is inside the elements of an
+// array field that no positional operator is applied to.
+func (f UpdateField) crossesArray() bool {
+	if f.Positional == "" {
+		return f.FieldReference.crossesArray()
+	}
+	return f.FieldReference[:f.PositionalDepth].crossesArray() ||
+		f.FieldReference[f.PositionalDepth:].crossesArray()
+}
+
+// Positional is a positional operator to update elements of an array field
+type Positional string
+
+// Positional constants
+const (
+	// PositionalFirst updates the first array element that matches the query
+	PositionalFirst Positional = "FIRST"
+	// PositionalFiltered updates all array elements that match the query
+	PositionalFiltered Positional = "FILTERED"
+)
+
+var positionalKeywords = map[string]Positional{
+	"Dollar": PositionalFirst,
+	"Elem":   PositionalFiltered,
+}
+
+// PushEachModifiers stores optional modifiers of PushEach update operator
+type PushEachModifiers struct {
+	// Position inserts the elements at the given index of the array. Nil
+	// means that the elements are appended to the end of the array.
+	Position *int
+	// Slice keeps only the last Slice elements of the array after pushing. Zero
+	// means that the array length is not limited.
+	Slice int
+	// Sort sorts the array elements after pushing. Empty means that the
+	// elements are not sorted.
+	Sort Ordering
 }
 
 // UpdateOperator is a custom type that declares update operator to be used in
@@ -61,20 +173,34 @@ type UpdateOperator string
 
 // UpdateOperator constants
 const (
-	UpdateOperatorSet  UpdateOperator = "SET"
-	UpdateOperatorPush UpdateOperator = "PUSH"
-	UpdateOperatorInc  UpdateOperator = "INC"
+	UpdateOperatorSet         UpdateOperator = "SET"
+	UpdateOperatorPush        UpdateOperator = "PUSH"
+	UpdateOperatorInc         UpdateOperator = "INC"
+	UpdateOperatorUnset       UpdateOperator = "UNSET"
+	UpdateOperatorPull        UpdateOperator = "PULL"
+	UpdateOperatorPullAll     UpdateOperator = "PULL_ALL"
+	UpdateOperatorAddToSet    UpdateOperator = "ADD_TO_SET"
+	UpdateOperatorPushEach    UpdateOperator = "PUSH_EACH"
+	UpdateOperatorMin         UpdateOperator = "MIN"
+	UpdateOperatorMax         UpdateOperator = "MAX"
+	UpdateOperatorMul         UpdateOperator = "MUL"
+	UpdateOperatorCurrentDate UpdateOperator = "CURRENT_DATE"
+	UpdateOperatorSetOnInsert UpdateOperator = "SET_ON_INSERT"
 )
 
 // NumberOfArguments returns number of arguments required to perform an update operation
 func (o UpdateOperator) NumberOfArguments() int {
+	switch o {
+	case UpdateOperatorUnset, UpdateOperatorCurrentDate:
+		return 0
+	}
 	return 1
 }
 
 // ArgumentType returns type that is required for function parameter
 func (o UpdateOperator) ArgumentType(fieldType types.Type) types.Type {
 	switch o {
-	case UpdateOperatorPush:
+	case UpdateOperatorPush, UpdateOperatorPull, UpdateOperatorAddToSet:
 		sliceType := fieldType.(*types.Slice)
 		return sliceType.Elem()
 	default:
@@ -82,8 +208,8 @@ func (o UpdateOperator) ArgumentType(fieldType types.Type) types.Type {
 	}
 }
 
-func (p interfaceMethodParser) parseUpdateOperation(tokens []string) (Operation, error) {
-	mode, err := p.extractIntOrBoolReturns(p.Signature.Results())
+func (p interfaceMethodParser) parseUpdateOperation(tokens []string, upsert bool) (Operation, error) {
+	mode, returnModified, upsertedIDType, err := p.extractUpdateReturns(p.Signature.Results(), upsert)
 	if err != nil {
 		return nil, err
 	}
@@ -99,20 +225,107 @@ func (p interfaceMethodParser) parseUpdateOperation(tokens []string) (Operation,
 		return nil, err
 	}
 
+	if !upsert && hasUpdateOperator(update, UpdateOperatorSetOnInsert) {
+		return nil, ErrSetOnInsertWithoutUpsert
+	}
+
 	querySpec, err := p.parseQuery(queryTokens, 1+update.NumberOfArguments())
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.validateQueryFromParams(p.Signature.Params(), 1+update.NumberOfArguments(), querySpec); err != nil {
+	querySpec, err = p.bindQueryParams(p.Signature.Params(), 1+update.NumberOfArguments(), querySpec)
+	if err != nil {
 		return nil, err
 	}
 
-	return UpdateOperation{
-		Update: update,
-		Mode:   mode,
-		Query:  querySpec,
-	}, nil
+	if err := validatePositionalUpdate(update, querySpec); err != nil {
+		return nil, err
+	}
+
+	operation := UpdateOperation{
+		Update:         update,
+		Mode:           mode,
+		Query:          querySpec,
+		Upsert:         upsert,
+		ReturnModified: returnModified,
+		UpsertedIDType: upsertedIDType,
+	}
+	if err := querySpec.validateRequiredPredicates(operation.Name()); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// extractUpdateReturns extracts the query mode from the returns of the update
+// method. The update method may return both matched and modified results such
+// as (bool, bool, error) or (int, int, error). The upsert method may also
+// return the upserted ID, typed as the model field with `_id` bson tag, right
+// before the error such as (bool, primitive.ObjectID, error).
+func (p interfaceMethodParser) extractUpdateReturns(returns *types.Tuple,
+	upsert bool) (QueryMode, bool, types.Type, error) {
+
+	mode, returnModified, err := p.extractMatchedReturns(returns)
+	if err == nil || !upsert || returns.Len() < 2 {
+		return mode, returnModified, nil, err
+	}
+
+	idField, ok := code.FindIDField(p.UnderlyingStruct)
+	upsertedIDIndex := returns.Len() - 2
+	if !ok || !types.Identical(returns.At(upsertedIDIndex).Type(), idField.Var.Type()) {
+		return "", false, nil, err
+	}
+
+	var vars []*types.Var
+	for i := 0; i < returns.Len(); i++ {
+		if i != upsertedIDIndex {
+			vars = append(vars, returns.At(i))
+		}
+	}
+
+	mode, returnModified, err = p.extractMatchedReturns(types.NewTuple(vars...))
+	if err != nil {
+		return "", false, nil, err
+	}
+	return mode, returnModified, idField.Var.Type(), nil
+}
+
+// extractMatchedReturns extracts the query mode from the matched and modified
+// results of the update method.
+func (p interfaceMethodParser) extractMatchedReturns(returns *types.Tuple) (QueryMode, bool, error) {
+	if returns.Len() != 3 {
+		mode, err := p.extractIntOrBoolReturns(returns)
+		return mode, false, err
+	}
+
+	if !types.Identical(returns.At(2).Type(), code.TypeError) {
+		return "", false, NewUnsupportedReturnError(returns.At(2).Type(), 2)
+	}
+
+	mode, ok := intOrBoolQueryMode(returns.At(0).Type())
+	if !ok {
+		return "", false, NewUnsupportedReturnError(returns.At(0).Type(), 0)
+	}
+
+	if !types.Identical(returns.At(1).Type(), returns.At(0).Type()) {
+		return "", false, NewUnsupportedReturnError(returns.At(1).Type(), 1)
+	}
+
+	return mode, true, nil
+}
+
+func hasUpdateOperator(update Update, operator UpdateOperator) bool {
+	updateFields, ok := update.(UpdateFields)
+	if !ok {
+		return false
+	}
+
+	for _, field := range updateFields {
+		if field.Operator == operator {
+			return true
+		}
+	}
+	return false
 }
 
 func (p interfaceMethodParser) parseUpdate(tokens []string) (Update, error) {
@@ -124,6 +337,18 @@ func (p interfaceMethodParser) parseUpdate(tokens []string) (Update, error) {
 		return UpdateModel{}, nil
 	}
 
+	if len(tokens) == 1 && tokens[0] == "Patch" {
+		if _, ok := resolveFieldByName(p.UnderlyingStruct, "Patch"); !ok {
+			return p.parseUpdatePatch()
+		}
+	}
+
+	if len(tokens) > 1 && tokens[0] == "Rename" {
+		if _, ok := resolveFieldByName(p.UnderlyingStruct, "Rename"); !ok {
+			return p.parseUpdateRename(tokens[1:])
+		}
+	}
+
 	updateFields, err := p.parseUpdateFieldsFromTokens(tokens)
 	if err != nil {
 		return nil, err
@@ -136,6 +361,121 @@ func (p interfaceMethodParser) parseUpdate(tokens []string) (Update, error) {
 	return updateFields, nil
 }
 
+// parseUpdatePatch maps each field of the patch struct in the second parameter
+// to the model field of the same name. The patch fields must be pointers to
+// the type of the model fields. The patch struct is passed by value so that
+// the generated method has no nil patch to handle.
+func (p interfaceMethodParser) parseUpdatePatch() (Update, error) {
+	if p.Signature.Params().Len() <= 1 {
+		return nil, ErrInvalidUpdateFields
+	}
+
+	patchType := p.Signature.Params().At(1).Type()
+	if _, ok := patchType.(*types.Pointer); ok {
+		return nil, ErrPointerPatchParam
+	}
+	patchStruct, ok := patchType.Underlying().(*types.Struct)
+	if !ok {
+		return nil, ErrInvalidUpdateFields
+	}
+
+	var update UpdatePatch
+	for i := 0; i < patchStruct.NumFields(); i++ {
+		patchField := patchStruct.Field(i)
+
+		fieldReference, ok := resolveFieldByName(p.UnderlyingStruct, patchField.Name())
+		if !ok {
+			return nil, NewStructFieldNotFoundError([]string{patchField.Name()})
+		}
+
+		pointerType, ok := patchField.Type().(*types.Pointer)
+		if !ok {
+			return nil, NewPatchFieldNotPointerError(patchField.Name())
+		}
+
+		fieldType := fieldReference.ReferencedField().Var.Type()
+		if !types.Identical(pointerType.Elem(), fieldType) {
+			return nil, NewArgumentTypeNotMatchedError(patchField.Name(), types.NewPointer(fieldType),
+				patchField.Type())
+		}
+
+		update = append(update, UpdatePatchField{
+			Name:           patchField.Name(),
+			FieldReference: fieldReference,
+		})
+	}
+
+	return update, nil
+}
+
+// parseUpdateRename parses the tokens in the form of <From>To<To>. Both sides
+// must resolve to the model fields of the same type.
+func (p interfaceMethodParser) parseUpdateRename(tokens []string) (Update, error) {
+	// the first unresolved field is reported if no split of the tokens
+	// resolves both fields
+	var notFoundTokens []string
+	for i, token := range tokens {
+		if token != "To" {
+			continue
+		}
+
+		from, ok := resolveStructField(p.UnderlyingStruct, tokens[:i])
+		if !ok {
+			if notFoundTokens == nil {
+				notFoundTokens = tokens[:i]
+			}
+			continue
+		}
+
+		to, ok := resolveStructField(p.UnderlyingStruct, tokens[i+1:])
+		if !ok {
+			if notFoundTokens == nil {
+				notFoundTokens = tokens[i+1:]
+			}
+			continue
+		}
+
+		if from.ReferencingCode() == to.ReferencingCode() {
+			return nil, ErrInvalidUpdateFields
+		}
+		if from.crossesArray() {
+			return nil, NewPositionalRequiredError(from)
+		}
+		if to.crossesArray() {
+			return nil, NewPositionalRequiredError(to)
+		}
+		if !types.Identical(from.ReferencedField().Var.Type(), to.ReferencedField().Var.Type()) {
+			return nil, NewIncompatibleRenameError(from, to)
+		}
+
+		return UpdateRename{
+			From: from,
+			To:   to,
+		}, nil
+	}
+
+	if notFoundTokens == nil {
+		return nil, ErrInvalidUpdateFields
+	}
+	return nil, NewStructFieldNotFoundError(notFoundTokens)
+}
+
+// resolveFieldByName finds the top-level model field that has the exact name.
+func resolveFieldByName(structModel *types.Struct, name string) (FieldReference, bool) {
+	for i := 0; i < structModel.NumFields(); i++ {
+		field := structModel.Field(i)
+		if field.Name() == name {
+			return FieldReference{
+				code.StructField{
+					Var: field,
+					Tag: reflect.StructTag(structModel.Tag(i)),
+				},
+			}, true
+		}
+	}
+	return nil, false
+}
+
 func (p interfaceMethodParser) parseUpdateFieldsFromTokens(tokens []string) (UpdateFields, error) {
 	updateFieldTokens, ok := splitByAnd(tokens)
 	if !ok {
@@ -158,59 +498,254 @@ func (p interfaceMethodParser) parseUpdateFieldsFromTokens(tokens []string) (Upd
 	return updateFields, nil
 }
 
+// updateOperatorKeywords maps the keywords written after the field name to
+// their update operators.
+var updateOperatorKeywords = []struct {
+	Tokens   []string
+	Operator UpdateOperator
+}{
+	{Tokens: []string{"Push"}, Operator: UpdateOperatorPush},
+	{Tokens: []string{"Inc"}, Operator: UpdateOperatorInc},
+	{Tokens: []string{"Unset"}, Operator: UpdateOperatorUnset},
+	{Tokens: []string{"Pull", "All"}, Operator: UpdateOperatorPullAll},
+	{Tokens: []string{"Pull"}, Operator: UpdateOperatorPull},
+	{Tokens: []string{"Add", "To", "Set"}, Operator: UpdateOperatorAddToSet},
+	{Tokens: []string{"Min"}, Operator: UpdateOperatorMin},
+	{Tokens: []string{"Max"}, Operator: UpdateOperatorMax},
+	{Tokens: []string{"Mul"}, Operator: UpdateOperatorMul},
+	{Tokens: []string{"Current", "Date"}, Operator: UpdateOperatorCurrentDate},
+	{Tokens: []string{"Set", "On", "Insert"}, Operator: UpdateOperatorSetOnInsert},
+}
+
 func (p interfaceMethodParser) parseUpdateField(t []string,
 	paramIndex int) (UpdateField, error) {
 
-	if len(t) > 1 && t[len(t)-1] == "Push" {
-		return p.createUpdateField(t[:len(t)-1], UpdateOperatorPush, paramIndex)
+	if updateField, ok, err := p.parsePushEachUpdateField(t, paramIndex); ok {
+		return updateField, err
 	}
-	if len(t) > 1 && t[len(t)-1] == "Inc" {
-		return p.createUpdateField(t[:len(t)-1], UpdateOperatorInc, paramIndex)
+
+	for _, keyword := range updateOperatorKeywords {
+		if len(t) > len(keyword.Tokens) && endsWith(t, keyword.Tokens...) {
+			return p.createUpdateField(t[:len(t)-len(keyword.Tokens)], keyword.Operator, paramIndex)
+		}
 	}
 	return p.createUpdateField(t, UpdateOperatorSet, paramIndex)
 }
 
+// parsePushEachUpdateField parses update field with PushEach operator which
+// can be followed by the modifiers e.g. PushEachSlice50SortDesc.
+func (p interfaceMethodParser) parsePushEachUpdateField(t []string,
+	paramIndex int) (UpdateField, bool, error) {
+
+	for i := len(t) - 2; i > 0; i-- {
+		if t[i] != "Push" || t[i+1] != "Each" {
+			continue
+		}
+
+		modifiers, err := parsePushEachModifiers(t[i+2:])
+		if err != nil {
+			return UpdateField{}, true, err
+		}
+
+		updateField, err := p.createUpdateField(t[:i], UpdateOperatorPushEach, paramIndex)
+		if err != nil {
+			return UpdateField{}, true, err
+		}
+		updateField.PushEach = modifiers
+		return updateField, true, nil
+	}
+
+	return UpdateField{}, false, nil
+}
+
+func parsePushEachModifiers(t []string) (PushEachModifiers, error) {
+	var modifiers PushEachModifiers
+
+	if len(t) >= 1 && t[0] == "Position" {
+		if len(t) < 2 {
+			return PushEachModifiers{}, ErrInvalidUpdateFields
+		}
+
+		position, err := strconv.Atoi(t[1])
+		if err != nil {
+			return PushEachModifiers{}, ErrInvalidUpdateFields
+		}
+		modifiers.Position = &position
+		t = t[2:]
+	}
+
+	if len(t) >= 1 && t[0] == "Slice" {
+		if len(t) < 2 {
+			return PushEachModifiers{}, ErrInvalidUpdateFields
+		}
+
+		slice, err := strconv.Atoi(t[1])
+		if err != nil {
+			return PushEachModifiers{}, ErrInvalidUpdateFields
+		}
+
+		if slice <= 0 {
+			return PushEachModifiers{}, ErrPushEachSliceNonPositive
+		}
+		modifiers.Slice = slice
+		t = t[2:]
+	}
+
+	if len(t) >= 1 && t[0] == "Sort" {
+		switch {
+		case len(t) == 2 && t[1] == "Asc":
+			modifiers.Sort = OrderingAscending
+		case len(t) == 2 && t[1] == "Desc":
+			modifiers.Sort = OrderingDescending
+		default:
+			return PushEachModifiers{}, ErrInvalidUpdateFields
+		}
+		t = t[2:]
+	}
+
+	if len(t) > 0 {
+		return PushEachModifiers{}, ErrInvalidUpdateFields
+	}
+
+	return modifiers, nil
+}
+
 func (p interfaceMethodParser) createUpdateField(t []string,
 	operator UpdateOperator, paramIndex int) (UpdateField, error) {
 
-	fieldReference, ok := resolveStructField(p.UnderlyingStruct, t)
+	updateField, ok := p.resolveUpdateFieldReference(t)
 	if !ok {
 		return UpdateField{}, NewStructFieldNotFoundError(t)
 	}
+	if updateField.crossesArray() {
+		return UpdateField{}, NewPositionalRequiredError(updateField.FieldReference)
+	}
+	updateField.ParamIndex = paramIndex
+	updateField.Operator = operator
+
+	if !p.validateUpdateOperator(updateField.referencedType(), operator) {
+		return UpdateField{}, NewIncompatibleUpdateOperatorError(operator, updateField.FieldReference)
+	}
+
+	return updateField, nil
+}
+
+// resolveUpdateFieldReference resolves the update field from the tokens which
+// may contain a positional keyword after an array field.
+func (p interfaceMethodParser) resolveUpdateFieldReference(t []string) (UpdateField, bool) {
+	for i := 1; i < len(t); i++ {
+		positional, ok := positionalKeywords[t[i]]
+		if !ok {
+			continue
+		}
+
+		arrayFields, ok := resolveStructField(p.UnderlyingStruct, t[:i])
+		if !ok {
+			continue
+		}
+		sliceType, ok := arrayFields.ReferencedField().Var.Type().(*types.Slice)
+		if !ok {
+			continue
+		}
+
+		updateField := UpdateField{
+			FieldReference:  arrayFields,
+			Positional:      positional,
+			PositionalDepth: len(arrayFields),
+		}
+		if i == len(t)-1 {
+			return updateField, true
+		}
+
+		elemStruct, ok := getUnderlyingStructType(sliceType.Elem())
+		if !ok {
+			continue
+		}
+		elemFields, ok := resolveStructField(elemStruct, t[i+1:])
+		if !ok {
+			continue
+		}
 
-	if !p.validateUpdateOperator(fieldReference.ReferencedField().Var.Type(), operator) {
-		return UpdateField{}, NewIncompatibleUpdateOperatorError(operator, fieldReference)
+		updateField.FieldReference = append(arrayFields, elemFields...)
+		return updateField, true
 	}
 
-	return UpdateField{
-		FieldReference: fieldReference,
-		ParamIndex:     paramIndex,
-		Operator:       operator,
-	}, nil
+	fields, ok := resolveStructField(p.UnderlyingStruct, t)
+	return UpdateField{FieldReference: fields}, ok
 }
 
 func (p interfaceMethodParser) validateUpdateOperator(referencedType types.Type, operator UpdateOperator) bool {
 	switch operator {
-	case UpdateOperatorPush:
+	case UpdateOperatorPush, UpdateOperatorPull, UpdateOperatorPullAll, UpdateOperatorAddToSet,
+		UpdateOperatorPushEach:
 		_, ok := referencedType.(*types.Slice)
 		return ok
 
-	case UpdateOperatorInc:
-		switch t := referencedType.(type) {
-		case *types.Basic:
-			return t.Info()&types.IsNumeric != 0
+	case UpdateOperatorInc, UpdateOperatorMul:
+		return isNumericType(referencedType)
 
-		case *types.Pointer:
-			return p.validateUpdateOperator(t.Elem(), operator)
+	case UpdateOperatorMin, UpdateOperatorMax:
+		return isNumericType(referencedType) || code.IsTime(referencedType)
 
-		case *types.Named:
-			return p.validateUpdateOperator(t.Underlying(), operator)
+	case UpdateOperatorCurrentDate:
+		return code.IsTime(referencedType)
+	}
+	return true
+}
 
-		default:
-			return false
+func isNumericType(referencedType types.Type) bool {
+	switch t := referencedType.(type) {
+	case *types.Basic:
+		return t.Info()&types.IsNumeric != 0
+
+	case *types.Pointer:
+		return isNumericType(t.Elem())
+
+	case *types.Named:
+		return isNumericType(t.Underlying())
+
+	default:
+		return false
+	}
+}
+
+// validatePositionalUpdate ensures that the array fields updated with
+// positional operators are queried so that the array elements can be matched.
+// The query of the positional operator `$` must not be optional since `$` has
+// nothing to match when the predicate is omitted.
+func validatePositionalUpdate(update Update, querySpec QuerySpec) error {
+	updateFields, ok := update.(UpdateFields)
+	if !ok {
+		return nil
+	}
+
+	var filteredArrayFields FieldReference
+	for _, field := range updateFields {
+		if field.Positional == "" {
+			continue
+		}
+
+		arrayFields := field.FieldReference[:field.PositionalDepth]
+		if !querySpec.hasPredicateOn(arrayFields) {
+			return NewPositionalQueryRequiredError(arrayFields)
+		}
+		if field.Positional == PositionalFirst && !querySpec.hasRequiredPredicateOn(arrayFields) {
+			return NewOptionalPositionalQueryError(arrayFields)
+		}
+
+		if field.Positional == PositionalFiltered {
+			if querySpec.hasFieldComparisonOn(arrayFields) {
+				return NewFieldComparisonArrayFilterError(arrayFields)
+			}
+			if filteredArrayFields != nil && (len(filteredArrayFields) != len(arrayFields) ||
+				!arrayFields.HasPrefix(filteredArrayFields)) {
+				return ErrMultipleFilteredArrays
+			}
+			filteredArrayFields = arrayFields
 		}
 	}
-	return true
+
+	return nil
 }
 
 func (p interfaceMethodParser) validateUpdateFieldsWithParams(updateFields UpdateFields) error {
@@ -219,7 +754,7 @@ func (p interfaceMethodParser) validateUpdateFieldsWithParams(updateFields Updat
 			return ErrInvalidUpdateFields
 		}
 
-		expectedType := field.Operator.ArgumentType(field.FieldReference.ReferencedField().Var.Type())
+		expectedType := field.Operator.ArgumentType(field.referencedType())
 
 		for i := 0; i < field.Operator.NumberOfArguments(); i++ {
 			if !types.Identical(p.Signature.Params().At(field.ParamIndex+i).Type(), expectedType) {
@@ -21,6 +21,14 @@ func TestUpdateTypeName(t *testing.T) {
 			Update:       spec.UpdateFields{},
 			ExpectedName: "Fields",
 		},
+		{
+			Update:       spec.UpdatePatch{},
+			ExpectedName: "Patch",
+		},
+		{
+			Update:       spec.UpdateRename{},
+			ExpectedName: "Rename",
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -0,0 +1,18 @@
+package teststub
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Article struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Title     string             `bson:"title"`
+	CreatedAt time.Time          `bson:"created_at" repogen:"createdAt"`
+	UpdatedAt primitive.DateTime `bson:"updated_at" repogen:"updatedAt"`
+}
+
+type ArticlePatch struct {
+	Title *string
+}
@@ -0,0 +1,23 @@
+package teststub
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Session struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	RetryAfter int                `bson:"retry_after"`
+	NotBefore  time.Time          `bson:"not_before"`
+}
+
+type SessionRepositoryFind interface {
+	// Test find with field name ending with Before keyword
+	FindByNotBefore(ctx context.Context, notBefore time.Time) ([]*Session, error)
+	// Test find with After comparator on field name ending with Before keyword
+	FindByNotBeforeAfter(ctx context.Context, notBefore time.Time) ([]*Session, error)
+	// Test find with field name ending with After keyword
+	FindByRetryAfter(ctx context.Context, retryAfter int) ([]*Session, error)
+}
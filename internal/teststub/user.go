@@ -2,6 +2,7 @@ package teststub
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -19,6 +20,10 @@ type User struct {
 	Referrer       *User              `bson:"referrer"`
 	Enabled        bool               `bson:"enabled"`
 	ConsentHistory []ConsentHistory   `bson:"consent_history"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	LastLogin      primitive.DateTime `bson:"last_login"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+	Tags           []string           `bson:"tags"`
 	AccessToken    string
 }
 
@@ -32,13 +37,60 @@ type Contact struct {
 }
 
 type ConsentHistory struct {
-	ID    primitive.ObjectID
-	Value bool
+	ID    primitive.ObjectID `bson:"id"`
+	Value bool               `bson:"value"`
+}
+
+type UserPatch struct {
+	City *string
+	Age  *int
+	Name *Name
+}
+
+type NonPointerUserPatch struct {
+	City string
+}
+
+type MismatchedUserPatch struct {
+	Age *string
+}
+
+type UnknownUserPatch struct {
+	Country *string
+}
+
+type UserQuery struct {
+	City    *string
+	Gender  Gender
+	AgeFrom int
+	AgeTo   int
+}
+
+type MismatchedUserQuery struct {
+	City   int
+	Gender Gender
+}
+
+type MixedUserQuery struct {
+	AgeFrom *int
+	AgeTo   int
 }
 
 type UserRepositoryInsert interface {
 	InsertMany(ctx context.Context, users []*User) ([]interface{}, error)
+	// Test insert many returning typed inserted IDs
+	InsertManyIDs(ctx context.Context, users []*User) ([]primitive.ObjectID, error)
+	// Test insert many returning inserted models
+	InsertManyModels(ctx context.Context, users []*User) ([]*User, error)
+	// Test insert many models by value returning inserted models
+	InsertManyValueModels(ctx context.Context, users []User) ([]User, error)
+	// Test insert many models by value
+	InsertManyValues(ctx context.Context, users []User) ([]interface{}, error)
 	InsertOne(ctx context.Context, user *User) (interface{}, error)
+	// Test insert one returning typed inserted ID
+	InsertOneID(ctx context.Context, user *User) (primitive.ObjectID, error)
+	// Test insert one returning inserted model
+	InsertOneModel(ctx context.Context, user *User) (*User, error)
 }
 
 type UserRepositoryFind interface {
@@ -48,16 +100,22 @@ type UserRepositoryFind interface {
 	FindByAgeBetween(ctx context.Context, fromAge int, toAge int) ([]*User, error)
 	// Test find with GreaterThan operator
 	FindByAgeGreaterThan(ctx context.Context, age int) ([]*User, error)
+	// Test find with optional predicate
+	FindByAgeGreaterThanAndCity(ctx context.Context, age *int, city string) ([]*User, error)
 	// Test find with GreaterThanEqual operator
 	FindByAgeGreaterThanEqual(ctx context.Context, age int) ([]*User, error)
 	// Test find with LessThan operator
 	FindByAgeLessThan(ctx context.Context, age int) ([]*User, error)
 	// Test find with LessThanEqual operator
 	FindByAgeLessThanEqual(ctx context.Context, age int) ([]*User, error)
+	// Test find with negated Between operator
+	FindByAgeNotBetween(ctx context.Context, fromAge int, toAge int) ([]*User, error)
 	// Test find MANY mode
 	FindByCity(ctx context.Context, city string) ([]*User, error)
 	// Test find with And operator
 	FindByCityAndGender(ctx context.Context, city string, gender Gender) ([]*User, error)
+	// Test find with query struct parameter
+	FindByCityAndGenderAndAgeBetween(ctx context.Context, query UserQuery) ([]*User, error)
 	// Test find with In operator
 	FindByCityIn(ctx context.Context, cities []string) ([]*User, error)
 	// Test find with Not operator
@@ -76,22 +134,48 @@ type UserRepositoryFind interface {
 	FindByCityOrderByCityAndAgeDesc(ctx context.Context, city string) ([]*User, error)
 	// Test find with deep reference ordering
 	FindByCityOrderByNameFirst(ctx context.Context, city string) ([]*User, error)
+	// Test find by field inside an array of structs
+	FindByConsentHistoryID(ctx context.Context, consentHistoryID primitive.ObjectID) ([]*User, error)
+	// Test find with IsNotNull operator
+	FindByConsentHistoryIsNotNull(ctx context.Context) ([]*User, error)
+	// Test find with After operator
+	FindByCreatedAtAfter(ctx context.Context, createdAt time.Time) ([]*User, error)
+	// Test find with Before operator
+	FindByCreatedAtBefore(ctx context.Context, createdAt time.Time) ([]*User, error)
 	// Test find with False operator
 	FindByEnabledFalse(ctx context.Context) ([]*User, error)
+	// Test find with negated True operator
+	FindByEnabledNotTrue(ctx context.Context) ([]*User, error)
 	// Test find with True operator
 	FindByEnabledTrue(ctx context.Context) ([]*User, error)
+	// Test find with variadic In operator
+	FindByGenderAndCityIn(ctx context.Context, gender Gender, cities ...string) ([]*User, error)
 	// Test find ONE mode
 	FindByID(ctx context.Context, id primitive.ObjectID) (*User, error)
+	// Test find with WithinLast operator
+	FindByLastLoginWithinLast(ctx context.Context, duration time.Duration) ([]*User, error)
 	// Test find with deep referencing
 	FindByNameFirst(ctx context.Context, firstName string) ([]*User, error)
+	// Test find many returning models by value
+	FindByNameLast(ctx context.Context, lastName string) ([]User, error)
 	// Test find with multi-word arg
 	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error)
+	// Test find one returning model by value
+	FindByPhoneNumberAndCity(ctx context.Context, phoneNumber string, city string) (User, error)
 	// Test find with Exists operator
 	FindByReferrerExists(ctx context.Context) ([]*User, error)
 	// Test find with deep pointer referencing
 	FindByReferrerID(ctx context.Context, id primitive.ObjectID) ([]*User, error)
+	// Test find with IsNull operator
+	FindByReferrerIsNull(ctx context.Context) ([]*User, error)
 	// Test find with NotExists operator
 	FindByReferrerNotExists(ctx context.Context) ([]*User, error)
+	// Test find with TextSearch comparator
+	FindByTextSearch(ctx context.Context, query string) ([]*User, error)
+	// Test find with TextSearch comparator sorting by text score
+	FindByTextSearchAndCityOrderByScore(ctx context.Context, query string, city string) ([]*User, error)
+	// Test find with field comparison
+	FindByUpdatedAtGreaterThanCreatedAt(ctx context.Context) ([]*User, error)
 	// Test find Top N
 	FindTop5ByGenderOrderByAgeDesc(ctx context.Context, gender Gender) ([]*User, error)
 }
@@ -99,8 +183,19 @@ type UserRepositoryFind interface {
 type UserRepositoryUpdate interface {
 	// Test update inc operator
 	UpdateAgeIncByID(ctx context.Context, age int, id primitive.ObjectID) (bool, error)
+	// Test update min operator
+	UpdateAgeMinByID(ctx context.Context, age int, id primitive.ObjectID) (bool, error)
+	// Test update mul operator
+	UpdateAgeMulByID(ctx context.Context, age int, id primitive.ObjectID) (bool, error)
 	// Test update model ONE mode
 	UpdateByID(ctx context.Context, user *User, id primitive.ObjectID) (bool, error)
+	// Test update with matched and modified returns
+	UpdateCityByGender(ctx context.Context, city string, gender Gender) (int, int, error)
+	// Test update array element with positional operator
+	UpdateConsentHistoryDollarValueByIDAndConsentHistoryID(ctx context.Context, value bool, id primitive.ObjectID,
+		consentHistoryID primitive.ObjectID) (bool, error)
+	// Test update array elements with filtered positional operator
+	UpdateConsentHistoryElemValueByConsentHistoryValue(ctx context.Context, value bool, oldValue bool) (int, error)
 	// Test update push operator
 	UpdateConsentHistoryPushByID(ctx context.Context, consentHistoryItem ConsentHistory,
 		id primitive.ObjectID) (int, error)
@@ -113,8 +208,35 @@ type UserRepositoryUpdate interface {
 	UpdateGenderByAge(ctx context.Context, gender Gender, age int) (int, error)
 	// Test update field ONE mode
 	UpdateGenderByID(ctx context.Context, gender Gender, id primitive.ObjectID) (bool, error)
+	// Test update max operator on time field
+	UpdateLastLoginMaxByID(ctx context.Context, lastLogin primitive.DateTime, id primitive.ObjectID) (bool, error)
 	// Test update deep reference field
 	UpdateNameFirstByID(ctx context.Context, firstName string, id primitive.ObjectID) (bool, error)
+	// Test update with patch struct
+	UpdatePatchByID(ctx context.Context, patch UserPatch, id primitive.ObjectID) (bool, error)
+	// Test update unset operator along with other fields
+	UpdateReferrerUnsetAndCityByID(ctx context.Context, city string, id primitive.ObjectID) (bool, error)
+	// Test update rename
+	UpdateRenamePhoneNumberToCityAll(ctx context.Context) (int, error)
+	// Test update add to set operator
+	UpdateTagsAddToSetByID(ctx context.Context, tag string, id primitive.ObjectID) (bool, error)
+	// Test update pull all operator
+	UpdateTagsPullAllByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test update pull operator
+	UpdateTagsPullByID(ctx context.Context, tag string, id primitive.ObjectID) (bool, error)
+	// Test update push each operator
+	UpdateTagsPushEachByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test update push each operator with position modifier
+	UpdateTagsPushEachPosition0ByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test update push each operator with slice and sort modifiers
+	UpdateTagsPushEachSlice50SortDescByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test update current date operator
+	UpdateUpdatedAtCurrentDateByID(ctx context.Context, id primitive.ObjectID) (bool, error)
+	// Test upsert with set on insert operator
+	UpsertCityAndCreatedAtSetOnInsertByID(ctx context.Context, city string, createdAt time.Time,
+		id primitive.ObjectID) (bool, error)
+	// Test upsert with upserted ID return
+	UpsertCityByID(ctx context.Context, city string, id primitive.ObjectID) (bool, primitive.ObjectID, error)
 }
 
 type UserRepositoryDelete interface {
@@ -165,7 +287,7 @@ type UserRepositoryInvalidInsert interface {
 	// Test insert with invalid number of returns
 	Insert1(ctx context.Context, user *User) (*User, interface{}, error)
 	// Test insert with invalid return type
-	Insert2(ctx context.Context, user *User) (*User, error)
+	Insert2(ctx context.Context, user *User) (*Name, error)
 	// Test insert with unempty interface return
 	Insert3(ctx context.Context, user *User) (interface{ Foo() }, error)
 	// Test insert with no error return
@@ -176,6 +298,10 @@ type UserRepositoryInvalidInsert interface {
 	Insert6(ctx context.Context, user []*User) (interface{}, error)
 	// Test insert with mismatched model parameter for MANY mode
 	Insert7(ctx context.Context, user []*User) (interface{}, error)
+	// Test insert one returning inserted ID of type other than the ID field
+	Insert8(ctx context.Context, user *User) (string, error)
+	// Test insert many returning inserted models of type other than the parameter
+	Insert9(ctx context.Context, users []User) ([]*User, error)
 }
 
 type UserRepositoryInvalidFind interface {
@@ -191,16 +317,33 @@ type UserRepositoryInvalidFind interface {
 	FindAllOrderByAndAge(ctx context.Context) ([]*User, error)
 	// Test find with sort struct field not found
 	FindAllOrderByCountry(ctx context.Context) ([]*User, error)
+	// Test find with sort by text score without text search query
+	FindAllOrderByScore(ctx context.Context) ([]*User, error)
 	// Test find with no context parameter
 	FindByAge(age int) ([]*User, error)
+	// Test find with mixed optional arguments in query struct parameter
+	FindByAgeBetween(ctx context.Context, query MixedUserQuery) ([]*User, error)
+	// Test find with mixed optional arguments in positional parameters
+	FindByAgeNotBetween(ctx context.Context, fromAge *int, toAge int) ([]*User, error)
 	// Test find with misplaced query operator token (leftmost)
 	FindByAndGender(ctx context.Context, gender Gender) ([]*User, error)
 	// Test find with mismatched number of parameters
 	FindByCity(ctx context.Context, city string, gender Gender) ([]*User, error)
+	// Test find with query struct field not found
+	FindByCityAndEnabled(ctx context.Context, query UserQuery) ([]*User, error)
+	// Test find with incompatible struct field for Before comparator
+	FindByCityBefore(ctx context.Context, city string) ([]*User, error)
 	// Test find with mismatched parameter with In query
 	FindByCityIn(ctx context.Context, city string) ([]*User, error)
+	// Test find with incompatible struct field for IsNull comparator
+	FindByCityIsNull(ctx context.Context) ([]*User, error)
+	// Test find with mismatched query struct field type
+	FindByCityOrGender(ctx context.Context, query MismatchedUserQuery) ([]*User, error)
 	// Test find with query struct field not found
 	FindByCountry(ctx context.Context, country string) ([]*User, error)
+	// Test find with mismatched parameter type for WithinLast comparator
+	FindByCreatedAtLessThanAge(ctx context.Context) ([]*User, error)
+	FindByCreatedAtWithinLast(ctx context.Context, duration int) ([]*User, error)
 	// test find with mismatched parameter type
 	FindByGender(ctx context.Context, gender string) ([]*User, error)
 	// Test find with misplaced query operator token (rightmost)
@@ -214,9 +357,15 @@ type UserRepositoryInvalidFind interface {
 	// Test find with incompatible struct field for True comparator
 	FindByGenderTrue(ctx context.Context) ([]*User, error)
 	// Test find with invalid return type
-	FindByID(ctx context.Context, id primitive.ObjectID) (User, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (Name, error)
+	// Test find with mismatched struct parameter type
+	FindByLastLoginAfter(ctx context.Context, lastLogin time.Time) ([]*User, error)
 	// Test find with deep reference field not found
 	FindByNameMiddle(ctx context.Context, middleName string) ([]*User, error)
+	// Test find with variadic parameter for Equal comparator
+	FindByTags(ctx context.Context, tags ...string) ([]*User, error)
+	// Test find with mismatched parameter type for TextSearch comparator
+	FindByTextSearch(ctx context.Context, query int) ([]*User, error)
 	// Test find top with no number and query
 	FindTop(ctx context.Context) ([]*User, error)
 	// Test find top 0
@@ -234,7 +383,7 @@ type UserRepositoryInvalidUpdate interface {
 	// Test update without context parameter
 	UpdateAgeByGender(age int, gender Gender) (int, error)
 	// Test update with invalid number of returns
-	UpdateAgeByID(ctx context.Context, age int, id primitive.ObjectID) (bool, int, error)
+	UpdateAgeByID(ctx context.Context, age int, id primitive.ObjectID) (bool, bool, bool, error)
 	// Test update with ambiguous query
 	UpdateAgeByIDAndUsernameOrGender(ctx context.Context, age int, id primitive.ObjectID,
 		username string, gender Gender) (bool, error)
@@ -244,25 +393,79 @@ type UserRepositoryInvalidUpdate interface {
 	UpdateByID(ctx context.Context, id primitive.ObjectID) (bool, error)
 	// Test update without query
 	UpdateCity(ctx context.Context, city string) (bool, error)
+	// Test update with mismatched matched and modified return types
+	UpdateCityByAge(ctx context.Context, city string, age int) (int, bool, error)
 	// Test update with invalid return type
 	UpdateCityByID(ctx context.Context, city string, id primitive.ObjectID) (float64, error)
+	// Test update with current date operator in non-time field
+	UpdateCityCurrentDateByID(ctx context.Context, id primitive.ObjectID) (bool, error)
 	// Test update with inc operator in non-number field
 	UpdateCityIncByID(ctx context.Context, city string, id primitive.ObjectID) (bool, error)
+	// Test update with mul operator in non-number field
+	UpdateCityMulByID(ctx context.Context, city string, id primitive.ObjectID) (bool, error)
+	// Test update with pull operator in non-array field
+	UpdateCityPullByID(ctx context.Context, city string, id primitive.ObjectID) (bool, error)
+	// Test update with set on insert operator without upsert
+	UpdateCitySetOnInsertByID(ctx context.Context, city string, id primitive.ObjectID) (bool, error)
+	// Test update with positional operator without querying the array
+	UpdateConsentHistoryDollarValueByID(ctx context.Context, value bool, id primitive.ObjectID) (bool, error)
+	// Test update with positional operator with optional query on the array
+	UpdateConsentHistoryDollarValueByIDAndConsentHistoryID(ctx context.Context, value bool, id primitive.ObjectID,
+		consentHistoryID *primitive.ObjectID) (bool, error)
+	// Test update with filtered positional operator on multiple arrays
+	UpdateConsentHistoryElemValueAndReferrerConsentHistoryElemValueByConsentHistoryValueAndReferrerConsentHistoryValue(
+		ctx context.Context, value bool, referrerValue bool, oldValue bool, oldReferrerValue bool) (int, error)
+	// Test update with filtered positional operator with field comparison on the array
+	UpdateConsentHistoryElemValueByConsentHistoryIDGreaterThanID(ctx context.Context, value bool) (int, error)
 	// Test update with push operator with incorrect parameter type
 	UpdateConsentHistoryPushByID(ctx context.Context, consentHistoryItem []ConsentHistory,
 		id primitive.ObjectID) (int, error)
+	// Test update field inside an array without positional operator
+	UpdateConsentHistoryValueByID(ctx context.Context, value bool, id primitive.ObjectID) (bool, error)
 	// Test update field not found in struct
 	UpdateCountryByGender(ctx context.Context, country string, gender Gender) (int, error)
 	// Test update with insufficient function parameters
 	UpdateEnabledAll(ctx context.Context) (int, error)
 	// Test update with incorrect parameter type for query
 	UpdateEnabledByCity(ctx context.Context, enabled bool, city int) (bool, error)
+	// Test update with optional predicate
+	UpdateEnabledByAge(ctx context.Context, enabled bool, age *int) (int, error)
+	// Test update with optional query struct field
+	UpdateEnabledByCityAndGender(ctx context.Context, enabled bool, query UserQuery) (int, error)
 	// Test update with incorrect parameter type for update field
 	UpdateEnabledByGender(ctx context.Context, enabled int, gender Gender) (bool, error)
 	// Test update with no error return
 	UpdateEnabledByID(ctx context.Context, enabled bool, id primitive.ObjectID) (bool, bool)
+	// Test update with max operator in non-number and non-time field
+	UpdateEnabledMaxByID(ctx context.Context, enabled bool, id primitive.ObjectID) (bool, error)
 	// Test update with push operator in non-array field
 	UpdateGenderPushByID(ctx context.Context, gender Gender, id primitive.ObjectID) (bool, error)
+	// Test update with patch struct having non-pointer field
+	UpdatePatchByAge(ctx context.Context, patch NonPointerUserPatch, age int) (int, error)
+	// Test update with patch struct having field of incorrect type
+	UpdatePatchByCity(ctx context.Context, patch MismatchedUserPatch, city string) (int, error)
+	// Test update with pointer to patch struct
+	UpdatePatchByEnabled(ctx context.Context, patch *UserPatch, enabled bool) (int, error)
+	// Test update with patch struct having field not in the model
+	UpdatePatchByGender(ctx context.Context, patch UnknownUserPatch, gender Gender) (int, error)
+	// Test update rename with fields of different types
+	UpdateRenameAgeToCityAll(ctx context.Context) (int, error)
+	// Test update rename to the same field
+	UpdateRenameCityToCityAll(ctx context.Context) (int, error)
+	// Test update rename with field inside an array
+	UpdateRenameConsentHistoryValueToEnabledAll(ctx context.Context) (int, error)
+	// Test update rename with unknown field
+	UpdateRenameCountryToCityAll(ctx context.Context) (int, error)
+	// Test update with pull all operator with incorrect parameter type
+	UpdateTagsPullAllByID(ctx context.Context, tag string, id primitive.ObjectID) (bool, error)
+	// Test update with push each operator with invalid modifier
+	UpdateTagsPushEachLimit5ByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test update with push each operator with position modifier without index
+	UpdateTagsPushEachPositionByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test update with push each operator with non-positive slice
+	UpdateTagsPushEachSlice0ByID(ctx context.Context, tags []string, id primitive.ObjectID) (bool, error)
+	// Test upsert with upserted ID return of incorrect type
+	UpsertCityByID(ctx context.Context, city string, id primitive.ObjectID) (bool, string, error)
 }
 
 type UserRepositoryInvalidDelete interface {
@@ -272,10 +475,14 @@ type UserRepositoryInvalidDelete interface {
 	DeleteAll(ctx context.Context) (*User, int, error)
 	// Test delete with unsupported return type
 	DeleteByAge(ctx context.Context, age int) (float64, error)
+	// Test delete with optional predicate
+	DeleteByAgeGreaterThan(ctx context.Context, age *int) (int, error)
 	// Test delete with misplaced operator token (leftmost)
 	DeleteByAndGender(ctx context.Context, gender Gender) (bool, error)
 	// Test delete with no error return
 	DeleteByCity(ctx context.Context, city string) (int, bool)
+	// Test delete with optional query struct field
+	DeleteByCityAndGender(ctx context.Context, query UserQuery) (int, error)
 	// Test delete with mismatched parameter type for In operator
 	DeleteByCityIn(ctx context.Context, city string) (int, error)
 	// Test delete with query struct field not found
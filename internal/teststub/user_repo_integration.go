@@ -12,9 +12,16 @@ type UserRepositoryIntegration interface {
 	FindByAgeGreaterThanEqualOrderByAgeDesc(ctx context.Context, age int) ([]*User, error)
 	FindByAgeGreaterThanOrderByAgeAsc(ctx context.Context, age int) ([]*User, error)
 	FindByAgeBetween(ctx context.Context, ageFrom int, ageTo int) ([]*User, error)
+	FindByCityAndGenderAndAgeBetween(ctx context.Context, query UserQuery) ([]*User, error)
+	FindByCityIn(ctx context.Context, cities ...string) ([]*User, error)
+	FindByGenderAndAgeGreaterThan(ctx context.Context, gender Gender, age *int) ([]*User, error)
 	FindByGenderNotAndAgeLessThan(ctx context.Context, gender Gender, age int) ([]*User, error)
 	FindByGenderOrAge(ctx context.Context, gender Gender, age int) ([]*User, error)
 	FindByID(ctx context.Context, id primitive.ObjectID) (*User, error)
+	// FindByPhoneNumber returns ErrUserRepositoryIntegrationNotFound if no user has the phone number.
+	//
+	// repogen:notfound=sentinel
+	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error)
 	InsertMany(ctx context.Context, users []*User) ([]interface{}, error)
 	InsertOne(ctx context.Context, user *User) (interface{}, error)
 }
@@ -1,6 +1,7 @@
 package testutils
 
 import (
+	"go/ast"
 	"go/types"
 	"reflect"
 
@@ -11,14 +12,26 @@ import (
 var (
 	TypeContextNamed    *types.Named
 	TypeObjectIDNamed   *types.Named
+	TypeDateTimeNamed   *types.Named
 	TypeCollectionNamed *types.Named
+	TypeTimeNamed       *types.Named
+	TypeDurationNamed   *types.Named
 
-	Pkg                     *types.Package
-	TypeUserNamed           *types.Named
-	TypeUserStruct          *types.Struct
-	TypeGenderNamed         *types.Named
-	TypeNameStruct          *types.Struct
-	TypeConsentHistoryNamed *types.Named
+	Pkg                      *types.Package
+	Syntax                   []*ast.File
+	TypeUserNamed            *types.Named
+	TypeUserStruct           *types.Struct
+	TypeGenderNamed          *types.Named
+	TypeNameStruct           *types.Struct
+	TypeConsentHistoryNamed  *types.Named
+	TypeConsentHistoryStruct *types.Struct
+	TypeArticleNamed         *types.Named
+	TypeArticleStruct        *types.Struct
+	TypeArticlePatchNamed    *types.Named
+	TypeSessionNamed         *types.Named
+	TypeSessionStruct        *types.Struct
+	TypeUserPatchNamed       *types.Named
+	TypeUserQueryNamed       *types.Named
 )
 
 func init() {
@@ -30,11 +43,19 @@ func init() {
 	}
 	TypeContextNamed = contextPkgs[0].Types.Scope().Lookup("Context").Type().(*types.Named)
 
+	timePkgs, err := packages.Load(cfg, "time")
+	if err != nil {
+		panic(err)
+	}
+	TypeTimeNamed = timePkgs[0].Types.Scope().Lookup("Time").Type().(*types.Named)
+	TypeDurationNamed = timePkgs[0].Types.Scope().Lookup("Duration").Type().(*types.Named)
+
 	primitivePkgs, err := packages.Load(cfg, "go.mongodb.org/mongo-driver/bson/primitive")
 	if err != nil {
 		panic(err)
 	}
 	TypeObjectIDNamed = primitivePkgs[0].Types.Scope().Lookup("ObjectID").Type().(*types.Named)
+	TypeDateTimeNamed = primitivePkgs[0].Types.Scope().Lookup("DateTime").Type().(*types.Named)
 
 	mongoPkgs, err := packages.Load(cfg, "go.mongodb.org/mongo-driver/mongo")
 	if err != nil {
@@ -42,16 +63,26 @@ func init() {
 	}
 	TypeCollectionNamed = mongoPkgs[0].Types.Scope().Lookup("Collection").Type().(*types.Named)
 
-	stubPkgs, err := packages.Load(cfg, "../teststub")
+	stubCfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedSyntax}
+	stubPkgs, err := packages.Load(stubCfg, "../teststub")
 	if err != nil {
 		panic(err)
 	}
 	Pkg = stubPkgs[0].Types
+	Syntax = stubPkgs[0].Syntax
 	TypeUserNamed = Pkg.Scope().Lookup("User").Type().(*types.Named)
 	TypeUserStruct = TypeUserNamed.Underlying().(*types.Struct)
 	TypeGenderNamed = Pkg.Scope().Lookup("Gender").Type().(*types.Named)
 	TypeNameStruct = Pkg.Scope().Lookup("Name").Type().Underlying().(*types.Struct)
 	TypeConsentHistoryNamed = Pkg.Scope().Lookup("ConsentHistory").Type().(*types.Named)
+	TypeConsentHistoryStruct = TypeConsentHistoryNamed.Underlying().(*types.Struct)
+	TypeArticleNamed = Pkg.Scope().Lookup("Article").Type().(*types.Named)
+	TypeArticleStruct = TypeArticleNamed.Underlying().(*types.Struct)
+	TypeArticlePatchNamed = Pkg.Scope().Lookup("ArticlePatch").Type().(*types.Named)
+	TypeSessionNamed = Pkg.Scope().Lookup("Session").Type().(*types.Named)
+	TypeSessionStruct = TypeSessionNamed.Underlying().(*types.Struct)
+	TypeUserPatchNamed = Pkg.Scope().Lookup("UserPatch").Type().(*types.Named)
+	TypeUserQueryNamed = Pkg.Scope().Lookup("UserQuery").Type().(*types.Named)
 }
 
 func FindStructFieldByName(s *types.Struct, name string) code.StructField {
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/sunboyy/repogen/internal/generator"
+	"github.com/sunboyy/repogen/internal/mongo"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -43,6 +44,34 @@ func main() {
 		"",
 		"destination package path. If not set, will consider as in the same package as repository interface.",
 	)
+	notFoundPtr := flag.String(
+		"notfound",
+		"error",
+		"behavior of find one methods when no document is found: error, nil, sentinel or a sentinel error identifier. "+
+			"Can be overridden per method with `repogen:notfound=` doc comment directive.",
+	)
+	wrapErrorsPtr := flag.Bool(
+		"wrap-errors",
+		false,
+		"wrap errors returned by the generated methods with the repository and method name, "+
+			"and classify them into Err<Interface>NotFound, Err<Interface>DuplicateKey and Err<Interface>Timeout",
+	)
+	implNamePtr := flag.String(
+		"impl-name",
+		"",
+		"name of the implementation struct. If not set, will be the repository interface name suffixed with Mongo.",
+	)
+	unexportedImplPtr := flag.Bool(
+		"unexported-impl",
+		false,
+		"unexport the implementation struct by lowercasing the first letter of its name. "+
+			"The constructor will return the repository interface instead.",
+	)
+	constructorNamePtr := flag.String(
+		"constructor-name",
+		"",
+		"name of the constructor. If not set, will be the repository interface name prefixed with New.",
+	)
 	flag.Parse()
 
 	if *versionPtr {
@@ -60,12 +89,17 @@ func main() {
 	}
 
 	request := GenerationRequest{
-		Pkg:       *pkgPtr,
-		ModelName: *modelPtr,
-		RepoName:  *repoPtr,
-		Dest:      *destPtr,
-		ModelPkg:  *modelPkgPtr,
-		DestPkg:   *destPkgPtr,
+		Pkg:             *pkgPtr,
+		ModelName:       *modelPtr,
+		RepoName:        *repoPtr,
+		Dest:            *destPtr,
+		ModelPkg:        *modelPkgPtr,
+		DestPkg:         *destPkgPtr,
+		NotFound:        *notFoundPtr,
+		WrapErrors:      *wrapErrorsPtr,
+		ImplName:        *implNamePtr,
+		UnexportedImpl:  *unexportedImplPtr,
+		ConstructorName: *constructorNamePtr,
 	}
 	code, err := generateFromRequest(request)
 	if err != nil {
@@ -91,12 +125,17 @@ func main() {
 }
 
 type GenerationRequest struct {
-	Pkg       string
-	ModelName string
-	RepoName  string
-	Dest      string
-	ModelPkg  string
-	DestPkg   string
+	Pkg             string
+	ModelName       string
+	RepoName        string
+	Dest            string
+	ModelPkg        string
+	DestPkg         string
+	NotFound        string
+	WrapErrors      bool
+	ImplName        string
+	UnexportedImpl  bool
+	ConstructorName string
 }
 
 func printUsage() {
@@ -119,7 +158,7 @@ var (
 
 func generateFromRequest(request GenerationRequest) (string, error) {
 	cfg := packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes,
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax,
 	}
 	if request.ModelPkg == "" {
 		request.ModelPkg = request.Pkg
@@ -150,6 +189,14 @@ func generateFromRequest(request GenerationRequest) (string, error) {
 		pkgM[destPkgID].Types,
 		request.ModelName,
 		request.RepoName,
+		generator.Options{
+			RepoSyntax:      pkgM[intfPkgID].Syntax,
+			NotFound:        mongo.NotFoundBehavior(request.NotFound),
+			WrapErrors:      request.WrapErrors,
+			ImplName:        request.ImplName,
+			UnexportedImpl:  request.UnexportedImpl,
+			ConstructorName: request.ConstructorName,
+		},
 	)
 }
 